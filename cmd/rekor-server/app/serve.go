@@ -37,11 +37,14 @@ import (
 	"github.com/spf13/viper"
 	"sigs.k8s.io/release-utils/version"
 
+	v1 "github.com/sigstore/protobuf-specs/gen/pb-go/common/v1"
+	"github.com/sigstore/rekor-tiles/v2/internal/admission"
 	"github.com/sigstore/rekor-tiles/v2/internal/algorithmregistry"
 	"github.com/sigstore/rekor-tiles/v2/internal/server"
 	"github.com/sigstore/rekor-tiles/v2/internal/signerverifier"
 	"github.com/sigstore/rekor-tiles/v2/internal/tessera"
 	"github.com/sigstore/rekor-tiles/v2/pkg/note"
+	"github.com/sigstore/rekor-tiles/v2/pkg/verifier/certificate"
 	"github.com/sigstore/sigstore/pkg/signature"
 	"github.com/sigstore/sigstore/pkg/signature/kms/gcp"
 	"github.com/sigstore/sigstore/pkg/signature/options"
@@ -134,12 +137,19 @@ var serveCmd = &cobra.Command{
 		// if in read-only mode, don't start the appender, because we don't want new checkpoints being published.
 		if !readOnly {
 			driverConfig := tessera.DriverConfiguration{
-				Hostname:            viper.GetString("hostname"),
-				GCPBucket:           viper.GetString("gcp-bucket"),
-				GCPSpannerDB:        viper.GetString("gcp-spanner"),
-				PersistentAntispam:  viper.GetBool("persistent-antispam"),
-				ASMaxBatchSize:      viper.GetUint("antispam-max-batch-size"),
-				ASPushbackThreshold: viper.GetUint("antispam-pushback-threshold"),
+				Hostname:             viper.GetString("hostname"),
+				GCPBucket:            viper.GetString("gcp-bucket"),
+				GCPSpannerDB:         viper.GetString("gcp-spanner"),
+				AWSBucket:            viper.GetString("aws-bucket"),
+				AWSMySQLDSN:          viper.GetString("aws-mysql-dsn"),
+				AWSS3Endpoint:        viper.GetString("aws-s3-endpoint"),
+				AWSMySQLMaxOpenConns: viper.GetInt("aws-mysql-max-open-conns"),
+				AWSMySQLMaxIdleConns: viper.GetInt("aws-mysql-max-idle-conns"),
+				StorageDir:           viper.GetString("storage-dir"),
+				PersistentAntispam:   viper.GetBool("persistent-antispam"),
+				ASMaxBatchSize:       viper.GetUint("antispam-max-batch-size"),
+				ASPushbackThreshold:  viper.GetUint("antispam-pushback-threshold"),
+				WitnessTimeout:       viper.GetDuration("witness-timeout"),
 			}
 			tesseraDriver, persistentAntispam, err := tessera.NewDriver(ctx, driverConfig)
 			if err != nil {
@@ -148,6 +158,18 @@ var serveCmd = &cobra.Command{
 			}
 			appendOptions = tessera.WithLifecycleOptions(appendOptions, viper.GetUint("batch-max-size"), viper.GetDuration("batch-max-age"), viper.GetDuration("checkpoint-interval"), viper.GetUint("pushback-max-outstanding"))
 			appendOptions = tessera.WithAntispamOptions(appendOptions, persistentAntispam)
+			if policyFile := viper.GetString("witness-policy-file"); policyFile != "" {
+				policy, err := os.ReadFile(policyFile)
+				if err != nil {
+					slog.Error("failed to read witness policy", "error", err)
+					os.Exit(1)
+				}
+				appendOptions, err = tessera.WithWitnessOptions(appendOptions, policy, viper.GetBool("witness-fail-open"))
+				if err != nil {
+					slog.Error("failed to initialize witness options", "error", err)
+					os.Exit(1)
+				}
+			}
 			tesseraStorage, shutdownFn, err = tessera.NewStorage(ctx, viper.GetString("hostname"), tesseraDriver, appendOptions)
 			if err != nil {
 				slog.Error("failed to initialize tessera storage", "error", err)
@@ -160,7 +182,29 @@ var serveCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		rekorServer := server.NewServer(tesseraStorage, readOnly, algorithmRegistry, logID)
+		var trustBundle *certificate.TrustBundle
+		if trustBundleFile := viper.GetString("client-certificate-trust-bundle"); trustBundleFile != "" {
+			trustBundle, err = certificate.LoadTrustBundle(trustBundleFile)
+			if err != nil {
+				slog.Error("failed to load certificate trust bundle", "error", err)
+				os.Exit(1)
+			}
+		}
+
+		var admissionPolicy admission.Policy
+		if policyFile := viper.GetString("admission-policy-file"); policyFile != "" {
+			admissionPolicy, err = admission.LoadCELPolicy(policyFile)
+			if err != nil {
+				slog.Error("failed to load admission policy", "error", err)
+				os.Exit(1)
+			}
+		}
+
+		rekorServer := server.NewServer(tesseraStorage, readOnly, algorithmRegistry, logID,
+			server.WithReturnExisting(viper.GetBool("return-existing-entries")),
+			server.WithPushbackRetryAfter(viper.GetDuration("pushback-retry-after")),
+			server.WithTrustBundle(trustBundle),
+			server.WithAdmissionPolicy(admissionPolicy, viper.GetBool("admission-policy-dry-run")))
 
 		server.Serve(
 			ctx,
@@ -216,6 +260,16 @@ func init() {
 	serveCmd.Flags().String("gcp-bucket", "", "GCS bucket for tile and checkpoint storage")
 	serveCmd.Flags().String("gcp-spanner", "", "Spanner database URI")
 
+	// aws configs
+	serveCmd.Flags().String("aws-bucket", "", "S3 bucket for tile and checkpoint storage")
+	serveCmd.Flags().String("aws-mysql-dsn", "", "MySQL DSN for the sequencer database, e.g. user:password@tcp(host:3306)/dbname")
+	serveCmd.Flags().String("aws-s3-endpoint", "", "optional endpoint for a custom S3-compatible service such as MinIO; credentials are read from the default AWS credential chain")
+	serveCmd.Flags().Int("aws-mysql-max-open-conns", 0, "maximum open connections to the MySQL database, defaults to 0 (unlimited)")
+	serveCmd.Flags().Int("aws-mysql-max-idle-conns", 2, "maximum idle connections to the MySQL database")
+
+	// posix configs
+	serveCmd.Flags().String("storage-dir", "", "directory for tile and checkpoint storage on a POSIX filesystem")
+
 	// checkpoint signing configs
 	serveCmd.Flags().String("signer-filepath", "", "path to the signing key")
 	serveCmd.Flags().String("signer-password", "", "password to decrypt the signing key")
@@ -231,22 +285,43 @@ func init() {
 	serveCmd.Flags().Duration("batch-max-age", tessera.DefaultBatchMaxAge, "the maximum amount of time a batch of entries will wait before being sent to the sequencer")
 	serveCmd.Flags().Duration("checkpoint-interval", tessera.DefaultCheckpointInterval, "the frequency at which a checkpoint will be published")
 	serveCmd.Flags().Uint("pushback-max-outstanding", tessera.DefaultPushbackMaxOutstanding, "the maximum number of 'in-flight' add requests")
+	serveCmd.Flags().Duration("pushback-retry-after", server.DefaultPushbackRetryAfter, "how long clients are asked to wait with a Retry-After header before retrying requests rejected due to pushback; rounded up to whole seconds for HTTP")
 	serveCmd.Flags().Duration("tlog-timeout", 30*time.Second, "timeout for terminating the tiles log queue")
 
 	// antispam configs
-	serveCmd.Flags().Bool("persistent-antispam", false, "whether to enable persistent antispam measures; only available for GCP storage backend and not supported by the Spanner storage emulator")
+	serveCmd.Flags().Bool("persistent-antispam", false, "whether to enable persistent antispam measures; for GCP, stored in a Spanner database named after --gcp-spanner with an -antispam suffix and not supported by the Spanner storage emulator; for AWS, stored in a MySQL database named after the --aws-mysql-dsn database with an _antispam suffix; for POSIX, stored in a Badger database in a sibling directory of --storage-dir with an -antispam suffix")
 	serveCmd.Flags().Uint("antispam-max-batch-size", 0, "maximum batch size for deduplication operations; will default to Tessera recommendation if unset; for Spanner, recommend around 1500 with 300 or more PU, or around 64 for smaller (e.g. 100 PU) instances")
 	serveCmd.Flags().Uint("antispam-pushback-threshold", 0, "maximum number of 'in-flight' add requests the antispam operator will allow before pushing back; will default to Tessera recommendation if unset")
 
+	// witnessing configs
+	serveCmd.Flags().String("witness-policy-file", "", "path to a witness policy in the Sigsum policy format, listing C2SP tlog-witness endpoints to cosign each checkpoint and the quorum required before it is published")
+	serveCmd.Flags().Bool("witness-fail-open", false, "whether to publish checkpoints even if the witness policy can't be satisfied; intended only for early adoption of witnessing")
+	serveCmd.Flags().Duration("witness-timeout", tessera.DefaultWitnessTimeout, "timeout for requests to witnesses")
+
+	// duplicate entry configs
+	serveCmd.Flags().Bool("return-existing-entries", false, "whether to respond to a submission of an entry already in the log with the existing entry and an inclusion proof against the latest checkpoint, rather than an error; clients may also request this per submission")
+
 	// allowed entry signing algorithms
 	keyAlgorithmTypes, err := defaultKeyAlgorithms()
 	if err != nil {
 		slog.Error(err.Error())
 		os.Exit(1)
 	}
-	keyAlgorithmHelp := fmt.Sprintf("signing algorithm to use for signing/hashing (allowed %s)", strings.Join(keyAlgorithmTypes, ", "))
+	optionalKeyAlgorithmTypes, err := keyAlgorithmFlags(algorithmregistry.OptionalClientSigningAlgorithms)
+	if err != nil {
+		slog.Error(err.Error())
+		os.Exit(1)
+	}
+	keyAlgorithmHelp := fmt.Sprintf("signing algorithm to use for signing/hashing (allowed %s, and experimental %s which are not enabled by default)", strings.Join(keyAlgorithmTypes, ", "), strings.Join(optionalKeyAlgorithmTypes, ", "))
 	serveCmd.Flags().StringSlice("client-signing-algorithms", keyAlgorithmTypes, keyAlgorithmHelp)
 
+	// trusted certificate authorities for entry certificates
+	serveCmd.Flags().String("client-certificate-trust-bundle", "", "path to a PEM file of root and intermediate certificates, such as Fulcio's certificate chain; if set, certificates in entries must chain to one of its self-signed roots, verified at the time each certificate was issued")
+
+	// admission policy configs
+	serveCmd.Flags().String("admission-policy-file", "", "path to a YAML file of CEL rules that every entry must satisfy to be added to the log, evaluated over the entry, its verifiers' identities and the request metadata")
+	serveCmd.Flags().Bool("admission-policy-dry-run", false, "whether to only log entries the admission policy would deny, rather than rejecting them")
+
 	if err := viper.BindPFlags(serveCmd.Flags()); err != nil {
 		slog.Error(err.Error())
 		os.Exit(1)
@@ -261,10 +336,13 @@ var hashAlgMap = map[string]crypto.Hash{
 }
 
 func defaultKeyAlgorithms() ([]string, error) {
-	allowedClientSigningAlgorithms := algorithmregistry.AllowedClientSigningAlgorithms
+	return keyAlgorithmFlags(algorithmregistry.AllowedClientSigningAlgorithms)
+}
+
+func keyAlgorithmFlags(keyAlgorithms []v1.PublicKeyDetails) ([]string, error) {
 	keyAlgorithmTypes := []string{}
-	for _, keyAlgorithm := range allowedClientSigningAlgorithms {
-		keyFlag, err := signature.FormatSignatureAlgorithmFlag(keyAlgorithm)
+	for _, keyAlgorithm := range keyAlgorithms {
+		keyFlag, err := algorithmregistry.FormatAlgorithmFlag(keyAlgorithm)
 		if err != nil {
 			return nil, err
 		}
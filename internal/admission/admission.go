@@ -0,0 +1,105 @@
+// Copyright 2025 The Sigstore Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admission
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	pb "github.com/sigstore/rekor-tiles/v2/pkg/generated/protobuf"
+	"github.com/sigstore/rekor-tiles/v2/pkg/verifier"
+	"github.com/sigstore/rekor-tiles/v2/pkg/verifier/certificate"
+	"github.com/sigstore/rekor-tiles/v2/pkg/verifier/identity"
+	"github.com/sigstore/rekor-tiles/v2/pkg/verifier/publickey"
+	"google.golang.org/grpc/metadata"
+)
+
+// Policy decides whether an entry may be added to the log, after the entry's
+// request has been validated and its signatures verified.
+type Policy interface {
+	// Admit returns a *DeniedError if the entry must not be added to the log, or
+	// another error if the policy could not be evaluated.
+	Admit(ctx context.Context, in *Input) error
+}
+
+// Input is the entry and request a policy decides on.
+type Input struct {
+	// Entry is the log entry created from the request
+	Entry *pb.Entry
+	// Identities are the identities of the entry's verifiers, in the order of the
+	// entry's signatures
+	Identities []identity.Identity
+	// PayloadType is the payload type of a DSSE envelope, which is not persisted in the
+	// entry, or empty for other entry types
+	PayloadType string
+	// Metadata is the request's gRPC metadata. HTTP request headers are prefixed
+	// with "grpcgateway-" by the HTTP gateway.
+	Metadata metadata.MD
+}
+
+// NewInput returns the input for a policy decision on entry, parsing the identity
+// of each of its verifiers.
+func NewInput(entry *pb.Entry, payloadType string, md metadata.MD) (*Input, error) {
+	var verifiers []*pb.Verifier
+	switch spec := entry.GetSpec().GetSpec().(type) {
+	case *pb.Spec_HashedRekordV002:
+		verifiers = append(verifiers, spec.HashedRekordV002.GetSignature().GetVerifier())
+	case *pb.Spec_DsseV002:
+		for _, sig := range spec.DsseV002.GetSignatures() {
+			verifiers = append(verifiers, sig.GetVerifier())
+		}
+	default:
+		return nil, fmt.Errorf("unsupported entry type %T", spec)
+	}
+	in := &Input{Entry: entry, PayloadType: payloadType, Metadata: md}
+	for i, v := range verifiers {
+		id, err := verifierIdentity(v)
+		if err != nil {
+			return nil, fmt.Errorf("verifier %d: %w", i, err)
+		}
+		in.Identities = append(in.Identities, id)
+	}
+	return in, nil
+}
+
+func verifierIdentity(v *pb.Verifier) (identity.Identity, error) {
+	var vf verifier.Verifier
+	var err error
+	switch {
+	case v.GetPublicKey() != nil:
+		vf, err = publickey.NewVerifier(bytes.NewReader(v.GetPublicKey().GetRawBytes()))
+	case v.GetX509Certificate() != nil:
+		vf, err = certificate.NewVerifier(bytes.NewReader(v.GetX509Certificate().GetRawBytes()))
+	default:
+		return identity.Identity{}, fmt.Errorf("must contain either a public key or X.509 certificate")
+	}
+	if err != nil {
+		return identity.Identity{}, err
+	}
+	return vf.Identity()
+}
+
+// DeniedError is returned when an entry is denied by a policy rule.
+type DeniedError struct {
+	// Rule is the name of the rule that denied the entry
+	Rule string
+	// Reason describes why the entry was denied
+	Reason string
+}
+
+func (e *DeniedError) Error() string {
+	return fmt.Sprintf("denied by admission rule %q: %s", e.Rule, e.Reason)
+}
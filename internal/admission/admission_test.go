@@ -0,0 +1,100 @@
+// Copyright 2025 The Sigstore Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admission
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"testing"
+	"time"
+
+	v1 "github.com/sigstore/protobuf-specs/gen/pb-go/common/v1"
+	pb "github.com/sigstore/rekor-tiles/v2/pkg/generated/protobuf"
+	fulcio "github.com/sigstore/sigstore-go/pkg/fulcio/certificate"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func newFulcioCert(t *testing.T) *x509.Certificate {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuer, err := asn1.MarshalWithParams("https://accounts.example.com", "utf8")
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:    big.NewInt(1),
+		NotBefore:       time.Now().Add(-time.Hour),
+		NotAfter:        time.Now().Add(time.Hour),
+		EmailAddresses:  []string{"user@example.com"},
+		ExtraExtensions: []pkix.Extension{{Id: fulcio.OIDIssuerV2, Value: issuer}},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &priv.PublicKey, priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+func TestNewInput(t *testing.T) {
+	cert := newFulcioCert(t)
+	pubKey, err := cryptoutils.MarshalPublicKeyToDER(cert.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certVerifier := &pb.Verifier{Verifier: &pb.Verifier_X509Certificate{X509Certificate: &v1.X509Certificate{RawBytes: cert.Raw}}}
+	keyVerifier := &pb.Verifier{Verifier: &pb.Verifier_PublicKey{PublicKey: &pb.PublicKey{RawBytes: pubKey}}}
+
+	hashedRekord := &pb.Entry{Kind: "hashedrekord", Spec: &pb.Spec{Spec: &pb.Spec_HashedRekordV002{
+		HashedRekordV002: &pb.HashedRekordLogEntryV002{Signature: &pb.Signature{Verifier: certVerifier}},
+	}}}
+	in, err := NewInput(hashedRekord, "", nil)
+	if assert.NoError(t, err) && assert.Len(t, in.Identities, 1) {
+		assert.Equal(t, []string{"user@example.com"}, in.Identities[0].EmailAddresses)
+		assert.Equal(t, "https://accounts.example.com", in.Identities[0].Extensions.Issuer)
+	}
+
+	dsse := &pb.Entry{Kind: "dsse", Spec: &pb.Spec{Spec: &pb.Spec_DsseV002{
+		DsseV002: &pb.DSSELogEntryV002{Signatures: []*pb.Signature{{Verifier: keyVerifier}, {Verifier: certVerifier}}},
+	}}}
+	in, err = NewInput(dsse, "text/plain", nil)
+	if assert.NoError(t, err) && assert.Len(t, in.Identities, 2) {
+		assert.Equal(t, pubKey, in.Identities[0].Raw)
+		assert.Equal(t, cert.Raw, in.Identities[1].Raw)
+		assert.Equal(t, "text/plain", in.PayloadType)
+	}
+
+	_, err = NewInput(&pb.Entry{}, "", nil)
+	assert.ErrorContains(t, err, "unsupported entry type")
+
+	invalid := &pb.Entry{Spec: &pb.Spec{Spec: &pb.Spec_HashedRekordV002{
+		HashedRekordV002: &pb.HashedRekordLogEntryV002{Signature: &pb.Signature{
+			Verifier: &pb.Verifier{Verifier: &pb.Verifier_PublicKey{PublicKey: &pb.PublicKey{RawBytes: []byte("not a key")}}},
+		}},
+	}}}
+	_, err = NewInput(invalid, "", nil)
+	assert.ErrorContains(t, err, "verifier 0")
+}
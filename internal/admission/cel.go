@@ -0,0 +1,190 @@
+// Copyright 2025 The Sigstore Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admission
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/google/cel-go/cel"
+	pb "github.com/sigstore/rekor-tiles/v2/pkg/generated/protobuf"
+	"github.com/sigstore/rekor-tiles/v2/pkg/verifier/identity"
+	"gopkg.in/yaml.v3"
+)
+
+// Rule is a CEL expression that must evaluate to true for an entry to be admitted.
+// Expressions can use the following variables:
+//   - entry, the dev.sigstore.rekor.v2.Entry message that will be added to the log
+//   - identities, a list of the identities of the entry's verifiers, each a map
+//     with keys fingerprint, certificate (true for certificates), emailAddresses,
+//     uris, otherName, issuer (the OIDC issuer of a Fulcio certificate) and
+//     extensions (the Fulcio certificate extensions, keyed by JSON field name,
+//     such as sourceRepositoryURI)
+//   - request, a map with keys payloadType (the DSSE envelope's payload type) and
+//     metadata (the request's gRPC metadata, a map of lowercase keys to lists of values)
+type Rule struct {
+	// Name identifies the rule in denials and logs
+	Name string `yaml:"name"`
+	// Expression is the CEL expression to evaluate
+	Expression string `yaml:"expression"`
+	// Message describes the rule's requirement, and is returned to clients when an
+	// entry is denied by the rule
+	Message string `yaml:"message"`
+}
+
+// PolicyConfig is the format of an admission policy file.
+type PolicyConfig struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+type compiledRule struct {
+	Rule
+	program cel.Program
+}
+
+// CELPolicy admits entries that satisfy all of its rules.
+type CELPolicy struct {
+	rules []compiledRule
+}
+
+// NewCELPolicy compiles the rules, returning an error if any rule is not a valid
+// CEL expression that evaluates to a boolean.
+func NewCELPolicy(rules []Rule) (*CELPolicy, error) {
+	env, err := cel.NewEnv(
+		cel.Types(&pb.Entry{}),
+		cel.Variable("entry", cel.ObjectType("dev.sigstore.rekor.v2.Entry")),
+		cel.Variable("identities", cel.ListType(cel.MapType(cel.StringType, cel.DynType))),
+		cel.Variable("request", cel.MapType(cel.StringType, cel.DynType)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating CEL environment: %w", err)
+	}
+	p := &CELPolicy{}
+	names := make(map[string]bool, len(rules))
+	for i, rule := range rules {
+		if rule.Name == "" {
+			return nil, fmt.Errorf("rule %d has no name", i)
+		}
+		if names[rule.Name] {
+			return nil, fmt.Errorf("duplicate rule %q", rule.Name)
+		}
+		names[rule.Name] = true
+		ast, issues := env.Compile(rule.Expression)
+		if issues.Err() != nil {
+			return nil, fmt.Errorf("compiling rule %q: %w", rule.Name, issues.Err())
+		}
+		if ast.OutputType() != cel.BoolType {
+			return nil, fmt.Errorf("rule %q must evaluate to a bool, not %v", rule.Name, ast.OutputType())
+		}
+		program, err := env.Program(ast)
+		if err != nil {
+			return nil, fmt.Errorf("creating program for rule %q: %w", rule.Name, err)
+		}
+		p.rules = append(p.rules, compiledRule{Rule: rule, program: program})
+	}
+	return p, nil
+}
+
+// LoadCELPolicy reads a policy from a YAML file in the PolicyConfig format, such as:
+//
+//	rules:
+//	  - name: github-actions
+//	    expression: identities.all(i, i.issuer == "https://token.actions.githubusercontent.com")
+//	    message: entries must be signed by GitHub Actions workflows
+func LoadCELPolicy(path string) (*CELPolicy, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading admission policy: %w", err)
+	}
+	var config PolicyConfig
+	dec := yaml.NewDecoder(bytes.NewReader(contents))
+	dec.KnownFields(true)
+	if err := dec.Decode(&config); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("parsing admission policy: %w", err)
+	}
+	return NewCELPolicy(config.Rules)
+}
+
+// Admit evaluates each rule in order, returning a *DeniedError for the first rule
+// that is not satisfied. Entries are also denied by rules that fail to evaluate,
+// such as by accessing a missing map key.
+func (p *CELPolicy) Admit(ctx context.Context, in *Input) error {
+	identities := make([]map[string]any, 0, len(in.Identities))
+	for _, id := range in.Identities {
+		v, err := identityValue(id)
+		if err != nil {
+			return err
+		}
+		identities = append(identities, v)
+	}
+	metadata := make(map[string][]string, len(in.Metadata))
+	for k, v := range in.Metadata {
+		metadata[k] = v
+	}
+	vars := map[string]any{
+		"entry":      in.Entry,
+		"identities": identities,
+		"request": map[string]any{
+			"payloadType": in.PayloadType,
+			"metadata":    metadata,
+		},
+	}
+	for _, rule := range p.rules {
+		out, _, err := rule.program.ContextEval(ctx, vars)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return &DeniedError{Rule: rule.Name, Reason: fmt.Sprintf("evaluating rule: %v", err)}
+		}
+		if out.Value() != true {
+			reason := rule.Message
+			if reason == "" {
+				reason = fmt.Sprintf("entry does not satisfy %s", rule.Expression)
+			}
+			return &DeniedError{Rule: rule.Name, Reason: reason}
+		}
+	}
+	return nil
+}
+
+// identityValue returns the CEL value of an identity.
+func identityValue(id identity.Identity) (map[string]any, error) {
+	// Use the JSON field names of the extensions, omitting empty values
+	encoded, err := json.Marshal(id.Extensions)
+	if err != nil {
+		return nil, fmt.Errorf("encoding certificate extensions: %w", err)
+	}
+	extensions := map[string]string{}
+	if err := json.Unmarshal(encoded, &extensions); err != nil {
+		return nil, fmt.Errorf("decoding certificate extensions: %w", err)
+	}
+	_, isCert := id.Crypto.(*x509.Certificate)
+	return map[string]any{
+		"fingerprint":    id.Fingerprint,
+		"certificate":    isCert,
+		"emailAddresses": append([]string{}, id.EmailAddresses...),
+		"uris":           append([]string{}, id.URIs...),
+		"otherName":      id.OtherName,
+		"issuer":         id.Extensions.Issuer,
+		"extensions":     extensions,
+	}, nil
+}
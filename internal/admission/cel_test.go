@@ -0,0 +1,146 @@
+// Copyright 2025 The Sigstore Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admission
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	v1 "github.com/sigstore/protobuf-specs/gen/pb-go/common/v1"
+	pb "github.com/sigstore/rekor-tiles/v2/pkg/generated/protobuf"
+	"github.com/sigstore/rekor-tiles/v2/pkg/verifier/identity"
+	fulcio "github.com/sigstore/sigstore-go/pkg/fulcio/certificate"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestNewCELPolicy(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		rules   []Rule
+		wantErr string
+	}{
+		{name: "no rules"},
+		{name: "valid", rules: []Rule{{Name: "kind", Expression: `entry.kind == "dsse"`}}},
+		{name: "missing name", rules: []Rule{{Expression: "true"}}, wantErr: "rule 0 has no name"},
+		{name: "duplicate name", rules: []Rule{{Name: "a", Expression: "true"}, {Name: "a", Expression: "false"}}, wantErr: `duplicate rule "a"`},
+		{name: "syntax error", rules: []Rule{{Name: "a", Expression: "entry.kind =="}}, wantErr: `compiling rule "a"`},
+		{name: "unknown variable", rules: []Rule{{Name: "a", Expression: "unknown == 1"}}, wantErr: `compiling rule "a"`},
+		{name: "not a bool", rules: []Rule{{Name: "a", Expression: "entry.kind"}}, wantErr: `rule "a" must evaluate to a bool`},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewCELPolicy(test.rules)
+			if test.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestCELPolicyAdmit(t *testing.T) {
+	cert := newFulcioCert(t)
+	in := &Input{
+		Entry: &pb.Entry{Kind: "dsse", ApiVersion: "0.0.2", Spec: &pb.Spec{Spec: &pb.Spec_DsseV002{
+			DsseV002: &pb.DSSELogEntryV002{PayloadHash: &v1.HashOutput{Algorithm: v1.HashAlgorithm_SHA2_256}},
+		}}},
+		Identities: []identity.Identity{
+			{
+				Crypto:         cert,
+				Fingerprint:    "cert",
+				EmailAddresses: []string{"user@example.com"},
+				Extensions: fulcio.Extensions{
+					Issuer:              "https://token.actions.githubusercontent.com",
+					SourceRepositoryURI: "https://github.com/sigstore/rekor-tiles",
+				},
+			},
+			{Crypto: cert.PublicKey, Fingerprint: "key"},
+		},
+		PayloadType: "application/vnd.in-toto+json",
+		Metadata:    metadata.Pairs("grpcgateway-user-agent", "client/1.0"),
+	}
+
+	for _, test := range []struct {
+		name       string
+		expression string
+		message    string
+		wantReason string
+	}{
+		{name: "entry fields", expression: `entry.kind == "dsse" && has(entry.spec.dsse_v002)`},
+		{name: "payload type", expression: `request.payloadType in ["application/vnd.in-toto+json"]`},
+		{name: "key fingerprints", expression: `identities.exists(i, i.fingerprint == "key")`},
+		{name: "issuer", expression: `identities.filter(i, i.certificate).all(i, i.issuer == "https://token.actions.githubusercontent.com")`},
+		{name: "email", expression: `identities.exists(i, "user@example.com" in i.emailAddresses)`},
+		{name: "extensions", expression: `identities[0].extensions.sourceRepositoryURI.startsWith("https://github.com/sigstore/")`},
+		{name: "metadata", expression: `request.metadata["grpcgateway-user-agent"][0] == "client/1.0"`},
+		{name: "denied with message", expression: `identities.all(i, i.certificate)`, message: "entries must be signed with certificates", wantReason: "entries must be signed with certificates"},
+		{name: "denied without message", expression: `request.payloadType == "text/plain"`, wantReason: `entry does not satisfy request.payloadType == "text/plain"`},
+		{name: "evaluation error", expression: `identities[1].extensions.sourceRepositoryURI == ""`, wantReason: "evaluating rule: no such key"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			p, err := NewCELPolicy([]Rule{{Name: "always", Expression: "true"}, {Name: "rule", Expression: test.expression, Message: test.message}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = p.Admit(context.Background(), in)
+			if test.wantReason == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var denied *DeniedError
+			if assert.True(t, errors.As(err, &denied)) {
+				assert.Equal(t, "rule", denied.Rule)
+				assert.Contains(t, denied.Reason, test.wantReason)
+			}
+		})
+	}
+}
+
+func TestLoadCELPolicy(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, contents string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	in := &Input{Entry: &pb.Entry{Kind: "hashedrekord"}}
+
+	p, err := LoadCELPolicy(write("policy.yaml", `
+rules:
+  - name: dsse-only
+    expression: entry.kind == "dsse"
+    message: only dsse entries are accepted
+`))
+	if assert.NoError(t, err) {
+		assert.ErrorContains(t, p.Admit(context.Background(), in), `denied by admission rule "dsse-only": only dsse entries are accepted`)
+	}
+
+	p, err = LoadCELPolicy(write("empty.yaml", ""))
+	if assert.NoError(t, err) {
+		assert.NoError(t, p.Admit(context.Background(), in))
+	}
+
+	_, err = LoadCELPolicy(write("unknown.yaml", "rules:\n  - name: a\n    expr: 'true'\n"))
+	assert.ErrorContains(t, err, "parsing admission policy")
+
+	_, err = LoadCELPolicy(filepath.Join(dir, "missing.yaml"))
+	assert.ErrorContains(t, err, "reading admission policy")
+}
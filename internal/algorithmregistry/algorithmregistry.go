@@ -19,10 +19,15 @@ import (
 	"crypto/ecdsa"
 	"crypto/ed25519"
 	"crypto/rsa"
+	"errors"
 	"fmt"
 	"reflect"
+	"slices"
 
+	"github.com/cloudflare/circl/sign/mldsa/mldsa65"
+	"github.com/cloudflare/circl/sign/mldsa/mldsa87"
 	v1 "github.com/sigstore/protobuf-specs/gen/pb-go/common/v1"
+	"github.com/sigstore/rekor-tiles/v2/internal/mldsa"
 	"github.com/sigstore/sigstore/pkg/signature"
 )
 
@@ -39,8 +44,20 @@ var (
 		v1.PublicKeyDetails_PKIX_ED25519,
 		v1.PublicKeyDetails_PKIX_ED25519_PH,
 	}
+	// OptionalClientSigningAlgorithms are supported signing algorithms for log entry
+	// signatures that must be enabled explicitly. ML-DSA is still experimental in Sigstore.
+	OptionalClientSigningAlgorithms = []v1.PublicKeyDetails{
+		v1.PublicKeyDetails_ML_DSA_65,
+		v1.PublicKeyDetails_ML_DSA_87,
+	}
 )
 
+// mldsaFlags are the flag values for ML-DSA algorithms, which sigstore can't parse or format.
+var mldsaFlags = map[v1.PublicKeyDetails]string{
+	v1.PublicKeyDetails_ML_DSA_65: "ml-dsa-65",
+	v1.PublicKeyDetails_ML_DSA_87: "ml-dsa-87",
+}
+
 type UnsupportedAlgorithm struct {
 	Pub crypto.PublicKey
 	Alg crypto.Hash
@@ -58,19 +75,101 @@ func (e *UnsupportedAlgorithm) Error() string {
 		return fmt.Sprintf("unsupported entry algorithm for ECDSA key, curve %s, digest %s", name, hash)
 	case ed25519.PublicKey:
 		return fmt.Sprintf("unsupported entry algorithm for Ed25519 key, digest %s", hash)
+	case *mldsa65.PublicKey, *mldsa87.PublicKey:
+		keyDetails, _ := mldsa.KeyDetails(v)
+		return fmt.Sprintf("unsupported entry algorithm for ML-DSA key, parameter set %s, digest %s", mldsa.Name(keyDetails), hash)
 	default:
 		return fmt.Sprintf("unsupported key type %s, digest %s", reflect.TypeOf(v), hash)
 	}
 }
 
+// Registry is the set of signing algorithms allowed for log entry signatures.
+// sigstore's AlgorithmRegistryConfig does not support ML-DSA, so ML-DSA algorithms
+// are tracked separately.
+type Registry struct {
+	config *signature.AlgorithmRegistryConfig
+	mldsa  []v1.PublicKeyDetails
+}
+
+// NewRegistry returns a registry allowing the given algorithms.
+func NewRegistry(algorithms []v1.PublicKeyDetails) (*Registry, error) {
+	r := &Registry{}
+	var sigstoreAlgorithms []v1.PublicKeyDetails
+	for _, a := range algorithms {
+		if mldsa.IsMLDSA(a) {
+			r.mldsa = append(r.mldsa, a)
+		} else {
+			sigstoreAlgorithms = append(sigstoreAlgorithms, a)
+		}
+	}
+	config, err := signature.NewAlgorithmRegistryConfig(sigstoreAlgorithms)
+	if err != nil {
+		return nil, err
+	}
+	r.config = config
+	return r, nil
+}
+
+// NewRegistryFromConfig returns a registry allowing the algorithms of config and
+// the ML-DSA algorithms mldsaAlgorithms.
+func NewRegistryFromConfig(config *signature.AlgorithmRegistryConfig, mldsaAlgorithms []v1.PublicKeyDetails) (*Registry, error) {
+	if config == nil {
+		return nil, errors.New("missing algorithm registry")
+	}
+	for _, a := range mldsaAlgorithms {
+		if !mldsa.IsMLDSA(a) {
+			return nil, fmt.Errorf("%v is not an ML-DSA algorithm", a)
+		}
+	}
+	return &Registry{config: config, mldsa: mldsaAlgorithms}, nil
+}
+
+// Config returns the allowed algorithms other than ML-DSA.
+func (r *Registry) Config() *signature.AlgorithmRegistryConfig {
+	return r.config
+}
+
+// MLDSA returns the allowed ML-DSA algorithms.
+func (r *Registry) MLDSA() []v1.PublicKeyDetails {
+	return r.mldsa
+}
+
+// IsAlgorithmPermitted returns whether the public key and message digest algorithm
+// are allowed. The digest algorithm is ignored for ML-DSA keys, which sign the
+// message rather than a digest.
+func (r *Registry) IsAlgorithmPermitted(key crypto.PublicKey, hash crypto.Hash) (bool, error) {
+	if keyDetails, ok := mldsa.KeyDetails(key); ok {
+		return slices.Contains(r.mldsa, keyDetails), nil
+	}
+	return r.config.IsAlgorithmPermitted(key, hash)
+}
+
+// ParseAlgorithmFlag parses a signing algorithm flag value, such as "ed25519" or "ml-dsa-65".
+func ParseAlgorithmFlag(flag string) (v1.PublicKeyDetails, error) {
+	for algorithm, f := range mldsaFlags {
+		if flag == f {
+			return algorithm, nil
+		}
+	}
+	return signature.ParseSignatureAlgorithmFlag(flag)
+}
+
+// FormatAlgorithmFlag returns the flag value for a signing algorithm.
+func FormatAlgorithmFlag(algorithm v1.PublicKeyDetails) (string, error) {
+	if f, ok := mldsaFlags[algorithm]; ok {
+		return f, nil
+	}
+	return signature.FormatSignatureAlgorithmFlag(algorithm)
+}
+
 // AlgorithmRegistry accepts a list of algorithms as strings, parses and formats them into a registry.
-func AlgorithmRegistry(algorithmOptions []string) (*signature.AlgorithmRegistryConfig, error) {
+func AlgorithmRegistry(algorithmOptions []string) (*Registry, error) {
 	var algorithms []v1.PublicKeyDetails
 	if algorithmOptions == nil {
 		algorithms = AllowedClientSigningAlgorithms
 	} else {
 		for _, a := range algorithmOptions {
-			algorithm, err := signature.ParseSignatureAlgorithmFlag(a)
+			algorithm, err := ParseAlgorithmFlag(a)
 			if err != nil {
 				return nil, fmt.Errorf("parsing signature algorithm flag: %w", err)
 			}
@@ -80,12 +179,12 @@ func AlgorithmRegistry(algorithmOptions []string) (*signature.AlgorithmRegistryC
 	algorithmsStr := make([]string, len(algorithms))
 	var err error
 	for i, a := range algorithms {
-		algorithmsStr[i], err = signature.FormatSignatureAlgorithmFlag(a)
+		algorithmsStr[i], err = FormatAlgorithmFlag(a)
 		if err != nil {
 			return nil, fmt.Errorf("formatting signature algorithm flag: %w", err)
 		}
 	}
-	algorithmRegistry, err := signature.NewAlgorithmRegistryConfig(algorithms)
+	algorithmRegistry, err := NewRegistry(algorithms)
 	if err != nil {
 		return nil, fmt.Errorf("getting algorithm registry: %w", err)
 	}
@@ -94,7 +193,7 @@ func AlgorithmRegistry(algorithmOptions []string) (*signature.AlgorithmRegistryC
 
 // CheckEntryAlgorithms checks that the combination public key and message
 // digest algorithm are allowed given an algorithm registry.
-func CheckEntryAlgorithms(pubKey crypto.PublicKey, alg crypto.Hash, algorithmRegistry *signature.AlgorithmRegistryConfig) (bool, error) {
+func CheckEntryAlgorithms(pubKey crypto.PublicKey, alg crypto.Hash, algorithmRegistry *Registry) (bool, error) {
 	// Check if all the verifiers public keys (together with the
 	// artifactHashValue) are allowed according to the policy
 	isPermitted, err := algorithmRegistry.IsAlgorithmPermitted(pubKey, alg)
@@ -21,9 +21,13 @@ import (
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
+	"slices"
 	"strings"
 	"testing"
 
+	"github.com/cloudflare/circl/sign/mldsa/mldsa65"
+	"github.com/cloudflare/circl/sign/mldsa/mldsa87"
+	v1 "github.com/sigstore/protobuf-specs/gen/pb-go/common/v1"
 	"github.com/sigstore/sigstore/pkg/signature"
 	"github.com/stretchr/testify/assert"
 )
@@ -46,6 +50,8 @@ func TestAlgorithmRegistry(t *testing.T) {
 				"ed25519",
 				"rsa-sign-pkcs1-3072-sha256",
 				"rsa-sign-pkcs1-4096-sha256",
+				"ml-dsa-65",
+				"ml-dsa-87",
 			},
 		},
 		{
@@ -103,6 +109,14 @@ func TestUnsupportedAlgorithm_Error(t *testing.T) {
 	ecdsaKeyP256 := generateECDSAKey(t, elliptic.P256())
 	ecdsaKeyP384 := generateECDSAKey(t, elliptic.P384())
 	ed25519Key := generateEd25519Key(t)
+	mldsa65Key, _, err := mldsa65.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mldsa87Key, _, err := mldsa87.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	testCases := []struct {
 		name string
@@ -149,6 +163,20 @@ func TestUnsupportedAlgorithm_Error(t *testing.T) {
 			},
 			want: "unsupported entry algorithm for Ed25519 key, digest SHA-256",
 		},
+		{
+			name: "ML-DSA-65",
+			err: UnsupportedAlgorithm{
+				Pub: mldsa65Key,
+			},
+			want: "unsupported entry algorithm for ML-DSA key, parameter set ML-DSA-65, digest unknown hash value 0",
+		},
+		{
+			name: "ML-DSA-87",
+			err: UnsupportedAlgorithm{
+				Pub: mldsa87Key,
+			},
+			want: "unsupported entry algorithm for ML-DSA key, parameter set ML-DSA-87, digest unknown hash value 0",
+		},
 		{
 			name: "nil public key",
 			err: UnsupportedAlgorithm{
@@ -218,3 +246,59 @@ func TestUnsupportedAlgorithmForAllAllowedAlgs(t *testing.T) {
 		}
 	}
 }
+
+func TestRegistryMLDSA(t *testing.T) {
+	mldsa65Key, _, err := mldsa65.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mldsa87Key, _, err := mldsa87.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecdsaKey := generateECDSAKey(t, elliptic.P256())
+
+	defaults, err := AlgorithmRegistry(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	permitted, err := defaults.IsAlgorithmPermitted(mldsa65Key, crypto.Hash(0))
+	assert.NoError(t, err)
+	assert.False(t, permitted, "ML-DSA must not be allowed by default")
+
+	registry, err := AlgorithmRegistry([]string{"ml-dsa-65", "ecdsa-sha2-256-nistp256"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	permitted, err = registry.IsAlgorithmPermitted(mldsa65Key, crypto.Hash(0))
+	assert.NoError(t, err)
+	assert.True(t, permitted)
+	permitted, err = registry.IsAlgorithmPermitted(mldsa87Key, crypto.Hash(0))
+	assert.NoError(t, err)
+	assert.False(t, permitted)
+	permitted, err = registry.IsAlgorithmPermitted(ecdsaKey, crypto.SHA256)
+	assert.NoError(t, err)
+	assert.True(t, permitted)
+
+	fromConfig, err := NewRegistryFromConfig(registry.Config(), registry.MLDSA())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, registry, fromConfig)
+	_, err = NewRegistryFromConfig(nil, nil)
+	assert.ErrorContains(t, err, "missing algorithm registry")
+	_, err = NewRegistryFromConfig(registry.Config(), []v1.PublicKeyDetails{v1.PublicKeyDetails_PKIX_ED25519})
+	assert.ErrorContains(t, err, "not an ML-DSA algorithm")
+
+	for _, a := range slices.Concat(AllowedClientSigningAlgorithms, OptionalClientSigningAlgorithms) {
+		flag, err := FormatAlgorithmFlag(a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		parsed, err := ParseAlgorithmFlag(flag)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, a, parsed)
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright 2025 The Sigstore Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mldsa
+
+import (
+	"crypto"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/cloudflare/circl/sign/mldsa/mldsa65"
+	"github.com/cloudflare/circl/sign/mldsa/mldsa87"
+	v1 "github.com/sigstore/protobuf-specs/gen/pb-go/common/v1"
+	"github.com/sigstore/sigstore/pkg/signature"
+)
+
+// ErrNotMLDSA is returned when parsing a public key that is not an ML-DSA key.
+var ErrNotMLDSA = errors.New("not an ML-DSA public key")
+
+var (
+	// OIDs from RFC 9881. circl's scheme OIDs omit the sigAlgs arc, so they can't be used.
+	oidMLDSA65 = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 3, 18}
+	oidMLDSA87 = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 3, 19}
+)
+
+type subjectPublicKeyInfo struct {
+	Algorithm pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+// ParsePKIXPublicKey parses a DER-encoded ML-DSA-65 or ML-DSA-87 public key in
+// PKIX, ASN.1 form, returning a *mldsa65.PublicKey or *mldsa87.PublicKey. The error
+// wraps ErrNotMLDSA if the key is not an ML-DSA key.
+func ParsePKIXPublicKey(der []byte) (crypto.PublicKey, error) {
+	var spki subjectPublicKeyInfo
+	rest, err := asn1.Unmarshal(der, &spki)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotMLDSA, err)
+	}
+	var pub interface{ UnmarshalBinary([]byte) error }
+	switch {
+	case spki.Algorithm.Algorithm.Equal(oidMLDSA65):
+		pub = new(mldsa65.PublicKey)
+	case spki.Algorithm.Algorithm.Equal(oidMLDSA87):
+		pub = new(mldsa87.PublicKey)
+	default:
+		return nil, ErrNotMLDSA
+	}
+	if len(rest) != 0 {
+		return nil, errors.New("trailing data after ML-DSA public key")
+	}
+	if len(spki.Algorithm.Parameters.FullBytes) != 0 {
+		return nil, errors.New("ML-DSA public key algorithm must not have parameters")
+	}
+	if spki.PublicKey.BitLength%8 != 0 {
+		return nil, errors.New("ML-DSA public key is not a whole number of bytes")
+	}
+	if err := pub.UnmarshalBinary(spki.PublicKey.Bytes); err != nil {
+		return nil, fmt.Errorf("parsing ML-DSA public key: %w", err)
+	}
+	return pub, nil
+}
+
+// MarshalPKIXPublicKey converts an ML-DSA public key to PKIX, ASN.1 DER form.
+func MarshalPKIXPublicKey(pub crypto.PublicKey) ([]byte, error) {
+	var oid asn1.ObjectIdentifier
+	var raw []byte
+	switch pk := pub.(type) {
+	case *mldsa65.PublicKey:
+		oid, raw = oidMLDSA65, pk.Bytes()
+	case *mldsa87.PublicKey:
+		oid, raw = oidMLDSA87, pk.Bytes()
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", pub)
+	}
+	return asn1.Marshal(subjectPublicKeyInfo{
+		Algorithm: pkix.AlgorithmIdentifier{Algorithm: oid},
+		PublicKey: asn1.BitString{Bytes: raw, BitLength: 8 * len(raw)},
+	})
+}
+
+// KeyDetails returns the key details for an ML-DSA public key, and false if the key
+// is not an ML-DSA key.
+func KeyDetails(pub crypto.PublicKey) (v1.PublicKeyDetails, bool) {
+	switch pub.(type) {
+	case *mldsa65.PublicKey:
+		return v1.PublicKeyDetails_ML_DSA_65, true
+	case *mldsa87.PublicKey:
+		return v1.PublicKeyDetails_ML_DSA_87, true
+	default:
+		return v1.PublicKeyDetails_PUBLIC_KEY_DETAILS_UNSPECIFIED, false
+	}
+}
+
+// IsMLDSA returns whether the key details are for an ML-DSA key.
+func IsMLDSA(keyDetails v1.PublicKeyDetails) bool {
+	return keyDetails == v1.PublicKeyDetails_ML_DSA_65 || keyDetails == v1.PublicKeyDetails_ML_DSA_87
+}
+
+// Name returns the name of the ML-DSA parameter set of the key details,
+// such as "ML-DSA-65".
+func Name(keyDetails v1.PublicKeyDetails) string {
+	switch keyDetails {
+	case v1.PublicKeyDetails_ML_DSA_65:
+		return mldsa65.Scheme().Name()
+	case v1.PublicKeyDetails_ML_DSA_87:
+		return mldsa87.Scheme().Name()
+	default:
+		return keyDetails.String()
+	}
+}
+
+// CheckKeyDetails returns an error if the public key or the key details are for
+// ML-DSA and do not match each other.
+func CheckKeyDetails(pub crypto.PublicKey, keyDetails v1.PublicKeyDetails) error {
+	pubDetails, ok := KeyDetails(pub)
+	if !ok && !IsMLDSA(keyDetails) {
+		return nil
+	}
+	if pubDetails != keyDetails {
+		return fmt.Errorf("key details %v do not match %T public key", keyDetails, pub)
+	}
+	return nil
+}
+
+// Verifier verifies pure ML-DSA signatures with an empty context string, and
+// implements signature.Verifier.
+type Verifier struct {
+	publicKey crypto.PublicKey
+}
+
+// LoadVerifier returns a Verifier for an ML-DSA-65 or ML-DSA-87 public key.
+func LoadVerifier(pub crypto.PublicKey) (*Verifier, error) {
+	if _, ok := KeyDetails(pub); !ok {
+		return nil, fmt.Errorf("unsupported key type %T", pub)
+	}
+	return &Verifier{publicKey: pub}, nil
+}
+
+// PublicKey returns the public key used to verify signatures.
+func (v *Verifier) PublicKey(_ ...signature.PublicKeyOption) (crypto.PublicKey, error) {
+	return v.publicKey, nil
+}
+
+// VerifySignature verifies the signature over the whole message. Digest options
+// are ignored, since pure ML-DSA signs the message itself.
+func (v *Verifier) VerifySignature(sig, message io.Reader, _ ...signature.VerifyOption) error {
+	if sig == nil || message == nil {
+		return errors.New("signature and message are required")
+	}
+	sigBytes, err := io.ReadAll(sig)
+	if err != nil {
+		return fmt.Errorf("reading signature: %w", err)
+	}
+	msg, err := io.ReadAll(message)
+	if err != nil {
+		return fmt.Errorf("reading message: %w", err)
+	}
+	var valid bool
+	switch pk := v.publicKey.(type) {
+	case *mldsa65.PublicKey:
+		valid = mldsa65.Verify(pk, msg, nil, sigBytes)
+	case *mldsa87.PublicKey:
+		valid = mldsa87.Verify(pk, msg, nil, sigBytes)
+	}
+	if !valid {
+		return errors.New("invalid ML-DSA signature")
+	}
+	return nil
+}
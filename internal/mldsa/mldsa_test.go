@@ -0,0 +1,109 @@
+// Copyright 2025 The Sigstore Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mldsa
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"testing"
+
+	"github.com/cloudflare/circl/sign/mldsa/mldsa65"
+	"github.com/cloudflare/circl/sign/mldsa/mldsa87"
+	v1 "github.com/sigstore/protobuf-specs/gen/pb-go/common/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMLDSA(t *testing.T) {
+	pub65, priv65, err := mldsa65.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub87, priv87, err := mldsa87.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := []byte("firmware")
+
+	for _, test := range []struct {
+		name       string
+		pub        crypto.PublicKey
+		priv       crypto.Signer
+		keyDetails v1.PublicKeyDetails
+		keyName    string
+	}{
+		{name: "ml-dsa-65", pub: pub65, priv: priv65, keyDetails: v1.PublicKeyDetails_ML_DSA_65, keyName: "ML-DSA-65"},
+		{name: "ml-dsa-87", pub: pub87, priv: priv87, keyDetails: v1.PublicKeyDetails_ML_DSA_87, keyName: "ML-DSA-87"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			der, err := MarshalPKIXPublicKey(test.pub)
+			if err != nil {
+				t.Fatal(err)
+			}
+			parsed, err := ParsePKIXPublicKey(der)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.True(t, parsed.(interface{ Equal(crypto.PublicKey) bool }).Equal(test.pub))
+
+			keyDetails, ok := KeyDetails(parsed)
+			assert.True(t, ok)
+			assert.Equal(t, test.keyDetails, keyDetails)
+			assert.True(t, IsMLDSA(keyDetails))
+			assert.Equal(t, test.keyName, Name(keyDetails))
+
+			sig, err := test.priv.Sign(rand.Reader, msg, crypto.Hash(0))
+			if err != nil {
+				t.Fatal(err)
+			}
+			v, err := LoadVerifier(parsed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.NoError(t, v.VerifySignature(bytes.NewReader(sig), bytes.NewReader(msg)))
+			assert.Error(t, v.VerifySignature(bytes.NewReader(sig), bytes.NewReader([]byte("other"))))
+
+			_, err = ParsePKIXPublicKey(append(der, 0))
+			assert.ErrorContains(t, err, "trailing data")
+			_, err = ParsePKIXPublicKey(der[:len(der)-1])
+			assert.Error(t, err)
+		})
+	}
+
+	t.Run("not ml-dsa", func(t *testing.T) {
+		ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		der, err := x509.MarshalPKIXPublicKey(ecKey.Public())
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = ParsePKIXPublicKey(der)
+		assert.ErrorIs(t, err, ErrNotMLDSA)
+		_, err = ParsePKIXPublicKey([]byte("not a key"))
+		assert.ErrorIs(t, err, ErrNotMLDSA)
+		_, err = MarshalPKIXPublicKey(ecKey.Public())
+		assert.Error(t, err)
+		_, ok := KeyDetails(ecKey.Public())
+		assert.False(t, ok)
+		assert.False(t, IsMLDSA(v1.PublicKeyDetails_PKIX_ED25519))
+		_, err = LoadVerifier(ecKey.Public())
+		assert.Error(t, err)
+	})
+}
@@ -84,6 +84,7 @@ func newHTTPProxy(ctx context.Context, config *HTTPConfig, grpcServer *grpcServe
 		runtime.WithMarshalerOption(runtime.MIMEWildcard, &strictMarshaler),
 		runtime.WithErrorHandler(customHTTPErrorHandler),
 		runtime.WithForwardResponseOption(httpResponseModifier),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeaderMatcher),
 		runtime.WithHealthzEndpoint(grpc_health_v1.NewHealthClient(cc)), // localhost:[port]/healthz
 	)
 
@@ -216,6 +217,15 @@ func httpResponseModifier(ctx context.Context, w http.ResponseWriter, _ proto.Me
 	return nil
 }
 
+// outgoingHeaderMatcher returns the Retry-After header as is, and prefixes all other
+// gRPC response metadata as the default matcher does.
+func outgoingHeaderMatcher(key string) (string, bool) {
+	if key == retryAfterHeader {
+		return "Retry-After", true
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
+
 // customHTTPErrorHandler remaps gRPC errors codes to HTTP status codes provided in an internal header.
 // This is needed to remap a gRPC code, such as Unimplemented, in certain instances to a more precise
 // HTTP status code.
@@ -16,16 +16,21 @@ package server
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	pbs "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
+	pb "github.com/sigstore/rekor-tiles/v2/pkg/generated/protobuf"
+	"github.com/sigstore/rekor-tiles/v2/pkg/types/validation"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 )
@@ -62,6 +67,41 @@ func TestServe_httpSmoke(t *testing.T) {
 	})
 }
 
+// validationErrorServer rejects every entry with a structured validation error.
+type validationErrorServer struct {
+	mockRekorServer
+}
+
+func (s *validationErrorServer) CreateEntry(ctx context.Context, _ *pb.CreateEntryRequest) (*pbs.TransparencyLogEntry, error) {
+	return nil, invalidRequestError(ctx, "invalid dsse request", "dsse_request_v002",
+		validation.Errorf(validation.ReasonUnverifiedDSSESignature, "envelope.signatures", "all signatures must have a key that verifies it"))
+}
+
+func TestServe_httpValidationError(t *testing.T) {
+	server := MockServer{Server: &validationErrorServer{}}
+	server.Start(t)
+	defer server.Stop(t)
+
+	checkValidationErrorDetails(t, fmt.Sprintf("http://%s", server.hc.HTTPTarget()))
+}
+
+// pushbackServer rejects every entry as if the log had reached max pushback.
+type pushbackServer struct {
+	mockRekorServer
+}
+
+func (s *pushbackServer) CreateEntry(ctx context.Context, _ *pb.CreateEntryRequest) (*pbs.TransparencyLogEntry, error) {
+	return nil, (&Server{retryAfter: 2 * time.Second}).pushbackError(ctx)
+}
+
+func TestServe_httpPushback(t *testing.T) {
+	server := MockServer{Server: &pushbackServer{}}
+	server.Start(t)
+	defer server.Stop(t)
+
+	checkPushbackRetryAfter(t, fmt.Sprintf("http://%s", server.hc.HTTPTarget()))
+}
+
 func TestServe_httpstls(t *testing.T) {
 	server := MockServer{}
 	server.StartTLS(t)
@@ -197,6 +237,61 @@ func checkExtraJSONFieldsErrors(t *testing.T, baseURL string, client *http.Clien
 	}
 }
 
+func checkValidationErrorDetails(t *testing.T, baseURL string) {
+	resp, err := http.Post(baseURL+"/api/v2/log/entries", "application/json", bytes.NewBufferString(`{"dsseRequestV002":{}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("got %d, want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Message string `json:"message"`
+		Details []struct {
+			Type            string `json:"@type"`
+			Reason          string `json:"reason"`
+			Domain          string `json:"domain"`
+			FieldViolations []struct {
+				Field string `json:"field"`
+			} `json:"fieldViolations"`
+		} `json:"details"`
+	}
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatalf("unmarshaling error body %s: %v", body, err)
+	}
+	if got.Message != "invalid dsse request" || len(got.Details) != 2 {
+		t.Fatalf("unexpected error body: %s", body)
+	}
+	if got.Details[0].Type != "type.googleapis.com/google.rpc.ErrorInfo" || got.Details[0].Reason != "UNVERIFIED_DSSE_SIGNATURE" || got.Details[0].Domain != "rekor.sigstore.dev" {
+		t.Errorf("unexpected error info: %s", body)
+	}
+	if got.Details[1].Type != "type.googleapis.com/google.rpc.BadRequest" || len(got.Details[1].FieldViolations) != 1 ||
+		got.Details[1].FieldViolations[0].Field != "dsse_request_v002.envelope.signatures" {
+		t.Errorf("unexpected bad request: %s", body)
+	}
+}
+
+func checkPushbackRetryAfter(t *testing.T, baseURL string) {
+	resp, err := http.Post(baseURL+"/api/v2/log/entries", "application/json", bytes.NewBufferString(`{"hashedRekordRequestV002":{}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("got %d, want %d", resp.StatusCode, http.StatusServiceUnavailable)
+	}
+	if got := resp.Header.Get("Retry-After"); got != "2" {
+		t.Errorf("got Retry-After %q, want %q", got, "2")
+	}
+}
+
 func checkHTTPPost(t *testing.T, baseURL string) {
 	checkHTTPPostWithClient(t, baseURL, http.DefaultClient)
 }
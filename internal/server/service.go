@@ -16,30 +16,55 @@ package server
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
+	"math"
+	"os"
+	"strconv"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/cyberphone/json-canonicalization/go/src/webpki.org/jsoncanonicalizer"
 	v1 "github.com/sigstore/protobuf-specs/gen/pb-go/common/v1"
 	pbs "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
+	"github.com/sigstore/rekor-tiles/v2/internal/admission"
+	"github.com/sigstore/rekor-tiles/v2/internal/algorithmregistry"
 	"github.com/sigstore/rekor-tiles/v2/internal/tessera"
+	"github.com/sigstore/rekor-tiles/v2/pkg/errorinfo"
 	pb "github.com/sigstore/rekor-tiles/v2/pkg/generated/protobuf"
 	"github.com/sigstore/rekor-tiles/v2/pkg/types/dsse"
 	"github.com/sigstore/rekor-tiles/v2/pkg/types/hashedrekord"
-	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/rekor-tiles/v2/pkg/types/validation"
+	"github.com/sigstore/rekor-tiles/v2/pkg/verifier/certificate"
 	ttessera "github.com/transparency-dev/tessera"
+	"github.com/transparency-dev/tessera/api/layout"
 	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
+const (
+	tileContentType       = "application/octet-stream"
+	checkpointContentType = "text/plain; charset=utf-8"
+	// retryAfterHeader is returned as the HTTP Retry-After header
+	retryAfterHeader = "retry-after"
+)
+
+// DefaultPushbackRetryAfter is how long clients are asked to wait before retrying
+// a request that was rejected because the log is overloaded.
+const DefaultPushbackRetryAfter = 1 * time.Second
+
 // rekorServer is the collection of methods that our grpc server must implement.
 type rekorServer interface {
 	pb.RekorServer
@@ -51,22 +76,71 @@ type Server struct {
 	grpc_health_v1.UnimplementedHealthServer
 	storage           tessera.Storage
 	readOnly          bool
-	algorithmRegistry *signature.AlgorithmRegistryConfig
+	algorithmRegistry *algorithmregistry.Registry
 	logID             []byte // Non-truncated digest of C2SP signed-note key ID
+	returnExisting    bool
+	retryAfter        time.Duration
+	trustBundle       *certificate.TrustBundle
+	admissionPolicy   admission.Policy
+	admissionDryRun   bool
+}
+
+// ServerOption configures optional behavior of the Rekor service.
+type ServerOption func(*Server)
+
+// WithReturnExisting configures the service to respond to a submission of an entry
+// that is already in the log with the existing entry and a fresh inclusion proof,
+// rather than an AlreadyExists error. Clients may also opt in per request.
+func WithReturnExisting(returnExisting bool) ServerOption {
+	return func(s *Server) {
+		s.returnExisting = returnExisting
+	}
+}
+
+// WithPushbackRetryAfter sets how long clients are asked to wait, with a Retry-After
+// header and google.rpc.RetryInfo, before retrying a request rejected due to pushback.
+func WithPushbackRetryAfter(retryAfter time.Duration) ServerOption {
+	return func(s *Server) {
+		s.retryAfter = retryAfter
+	}
+}
+
+// WithTrustBundle requires certificates in entries to chain to one of the bundle's
+// root certificates. By default, any certificate is accepted.
+func WithTrustBundle(trustBundle *certificate.TrustBundle) ServerOption {
+	return func(s *Server) {
+		s.trustBundle = trustBundle
+	}
+}
+
+// WithAdmissionPolicy evaluates the policy for each valid entry before it is added to
+// the log, rejecting entries the policy denies. In dry-run mode, denials are only logged.
+func WithAdmissionPolicy(policy admission.Policy, dryRun bool) ServerOption {
+	return func(s *Server) {
+		s.admissionPolicy = policy
+		s.admissionDryRun = dryRun
+	}
 }
 
-func NewServer(storage tessera.Storage, readOnly bool, algorithmRegistry *signature.AlgorithmRegistryConfig, logID []byte) *Server {
+func NewServer(storage tessera.Storage, readOnly bool, algorithmRegistry *algorithmregistry.Registry, logID []byte, opts ...ServerOption) *Server {
+	var s *Server
 	if readOnly {
-		return &Server{
+		s = &Server{
 			readOnly: readOnly,
 			logID:    logID,
 		}
+	} else {
+		s = &Server{
+			storage:           storage,
+			algorithmRegistry: algorithmRegistry,
+			logID:             logID,
+			retryAfter:        DefaultPushbackRetryAfter,
+		}
 	}
-	return &Server{
-		storage:           storage,
-		algorithmRegistry: algorithmRegistry,
-		logID:             logID,
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
 }
 
 func (s *Server) CreateEntry(ctx context.Context, req *pb.CreateEntryRequest) (*pbs.TransparencyLogEntry, error) {
@@ -83,10 +157,13 @@ func (s *Server) CreateEntry(ctx context.Context, req *pb.CreateEntryRequest) (*
 	switch req.GetSpec().(type) {
 	case *pb.CreateEntryRequest_HashedRekordRequestV002:
 		hr := req.GetHashedRekordRequestV002()
-		entry, err := hashedrekord.ToLogEntry(hr, s.algorithmRegistry)
+		entry, err := hashedrekord.ToLogEntry(hr, s.algorithmRegistry.Config(), hashedrekord.WithMLDSA(s.algorithmRegistry.MLDSA()...), hashedrekord.WithTrustBundle(s.trustBundle))
 		if err != nil {
 			slog.WarnContext(ctx, "failed validating hashedrekord request", "error", err.Error())
-			return nil, status.Errorf(codes.InvalidArgument, "invalid hashedrekord request")
+			return nil, invalidRequestError(ctx, "invalid hashedrekord request", "hashed_rekord_request_v002", err)
+		}
+		if err := s.admit(ctx, entry, ""); err != nil {
+			return nil, err
 		}
 		kv = &pbs.KindVersion{
 			Kind:    entry.Kind,
@@ -100,10 +177,13 @@ func (s *Server) CreateEntry(ctx context.Context, req *pb.CreateEntryRequest) (*
 		metricsCounter = getMetrics().newHashedRekordEntries
 	case *pb.CreateEntryRequest_DsseRequestV002:
 		ds := req.GetDsseRequestV002()
-		entry, err := dsse.ToLogEntry(ds, s.algorithmRegistry)
+		entry, err := dsse.ToLogEntry(ds, s.algorithmRegistry.Config(), dsse.WithMLDSA(s.algorithmRegistry.MLDSA()...), dsse.WithTrustBundle(s.trustBundle))
 		if err != nil {
 			slog.WarnContext(ctx, "failed validating dsse request", "error", err.Error())
-			return nil, status.Errorf(codes.InvalidArgument, "invalid dsse request")
+			return nil, invalidRequestError(ctx, "invalid dsse request", "dsse_request_v002", err)
+		}
+		if err := s.admit(ctx, entry, ds.GetEnvelope().GetPayloadType()); err != nil {
+			return nil, err
 		}
 		kv = &pbs.KindVersion{
 			Kind:    entry.Kind,
@@ -124,9 +204,20 @@ func (s *Server) CreateEntry(ctx context.Context, req *pb.CreateEntryRequest) (*
 		return nil, status.Errorf(codes.InvalidArgument, "invalid entry")
 	}
 	entry := ttessera.NewEntry(canonicalized)
-	tle, err := s.storage.Add(ctx, entry)
+	created := true
+	var tle *pbs.TransparencyLogEntry
+	if req.GetAsync() {
+		tle, err = s.storage.AddAsync(ctx, entry)
+	} else {
+		tle, err = s.storage.Add(ctx, entry)
+	}
+	var dupErr tessera.DuplicateError
+	if errors.As(err, &dupErr) && (s.returnExisting || req.GetReturnExisting()) {
+		created = false
+		tle, err = s.existingEntry(ctx, dupErr.Index(), entry, req.GetAsync())
+	}
 	if errors.Is(err, ttessera.ErrPushback) {
-		return nil, status.Errorf(codes.Unavailable, "reached max pushback; retry")
+		return nil, s.pushbackError(ctx)
 	}
 	if errors.Is(err, context.Canceled) {
 		// Returns a 499 Client Closed Request
@@ -150,19 +241,262 @@ func (s *Server) CreateEntry(ctx context.Context, req *pb.CreateEntryRequest) (*
 	// the checkpoint's key ID as a unique log identifier.
 	tle.LogId = &v1.LogId{KeyId: s.logID}
 
-	_ = grpc.SetHeader(ctx, metadata.Pairs(httpStatusCodeHeader, "201"))
+	if !created {
+		return tle, nil
+	}
+	if req.GetAsync() {
+		// The entry has been sequenced but not yet integrated into a published checkpoint
+		_ = grpc.SetHeader(ctx, metadata.Pairs(httpStatusCodeHeader, "202"))
+	} else {
+		_ = grpc.SetHeader(ctx, metadata.Pairs(httpStatusCodeHeader, "201"))
+	}
 	metricsCounter.Inc()
 	return tle, nil
 }
 
-func (s *Server) GetTile(context.Context, *pb.TileRequest) (*httpbody.HttpBody, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetTile not implemented")
+// invalidRequestError returns an InvalidArgument status with the given message. If err is a
+// validation error, the status also carries google.rpc.ErrorInfo and google.rpc.BadRequest
+// details with the error's reason code and the path of the request field at fault.
+func invalidRequestError(ctx context.Context, msg, fieldPrefix string, err error) error {
+	st := status.New(codes.InvalidArgument, msg)
+	var verr *validation.Error
+	if !errors.As(err, &verr) {
+		return st.Err()
+	}
+	withDetails, detailsErr := st.WithDetails(
+		&errdetails.ErrorInfo{
+			Reason: string(verr.Reason),
+			Domain: validation.Domain,
+		},
+		&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{{
+				Field:       fieldPrefix + "." + verr.Field,
+				Description: verr.Error(),
+			}},
+		},
+	)
+	if detailsErr != nil {
+		slog.WarnContext(ctx, "failed attaching error details", "error", detailsErr.Error())
+		return st.Err()
+	}
+	return withDetails.Err()
 }
-func (s *Server) GetEntryBundle(context.Context, *pb.EntryBundleRequest) (*httpbody.HttpBody, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetEntryBundle not implemented")
+
+// admit evaluates the admission policy for a valid entry, returning a PermissionDenied
+// status if the policy denies it, unless the policy is in dry-run mode.
+func (s *Server) admit(ctx context.Context, entry *pb.Entry, payloadType string) error {
+	if s.admissionPolicy == nil {
+		return nil
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	in, err := admission.NewInput(entry, payloadType, md)
+	if err != nil {
+		slog.WarnContext(ctx, "failed reading entry identities", "error", err.Error())
+		return status.Errorf(codes.InvalidArgument, "invalid entry")
+	}
+	err = s.admissionPolicy.Admit(ctx, in)
+	if err == nil {
+		return nil
+	}
+	var denied *admission.DeniedError
+	if !errors.As(err, &denied) {
+		slog.ErrorContext(ctx, "failed evaluating admission policy", "error", err.Error())
+		return status.Errorf(codes.Internal, "failed evaluating admission policy")
+	}
+	if s.admissionDryRun {
+		slog.WarnContext(ctx, "admission policy would deny entry", "rule", denied.Rule, "reason", denied.Reason, "kind", entry.Kind)
+		return nil
+	}
+	slog.WarnContext(ctx, "admission policy denied entry", "rule", denied.Rule, "reason", denied.Reason, "kind", entry.Kind)
+	st := status.New(codes.PermissionDenied, denied.Error())
+	withDetails, detailsErr := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   errorinfo.ReasonAdmissionDenied,
+		Domain:   errorinfo.AdmissionDomain,
+		Metadata: map[string]string{errorinfo.RuleKey: denied.Rule},
+	})
+	if detailsErr != nil {
+		slog.WarnContext(ctx, "failed attaching error details", "error", detailsErr.Error())
+		return st.Err()
+	}
+	return withDetails.Err()
 }
-func (s *Server) GetCheckpoint(context.Context, *emptypb.Empty) (*httpbody.HttpBody, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetCheckpoint not implemented")
+
+// notIntegratedError returns a NotFound status with a reason that lets clients tell an
+// entry that is not yet integrated apart from other NotFound responses.
+func notIntegratedError(ctx context.Context, index uint64) error {
+	st := status.Newf(codes.NotFound, "entry %d is not yet integrated into the log", index)
+	withDetails, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: errorinfo.ReasonNotIntegrated,
+		Domain: errorinfo.LogDomain,
+	})
+	if err != nil {
+		slog.WarnContext(ctx, "failed attaching error details", "error", err.Error())
+		return st.Err()
+	}
+	return withDetails.Err()
+}
+
+// pushbackError returns an Unavailable status with a pushback reason, asking the client
+// to retry after the configured delay, if any, which is also set as the Retry-After
+// header for HTTP clients.
+func (s *Server) pushbackError(ctx context.Context) error {
+	st := status.New(codes.Unavailable, "reached max pushback; retry")
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{
+		Reason: errorinfo.ReasonPushback,
+		Domain: errorinfo.LogDomain,
+	}}
+	if s.retryAfter > 0 {
+		// Retry-After is a whole number of seconds
+		seconds := int(math.Ceil(s.retryAfter.Seconds()))
+		_ = grpc.SetHeader(ctx, metadata.Pairs(retryAfterHeader, strconv.Itoa(seconds)))
+		details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(s.retryAfter)})
+	}
+	withDetails, err := st.WithDetails(details...)
+	if err != nil {
+		slog.WarnContext(ctx, "failed attaching error details", "error", err.Error())
+		return st.Err()
+	}
+	return withDetails.Err()
+}
+
+// existingEntry returns the entry already present in the log at the given index,
+// with an inclusion proof against the latest checkpoint unless the request was
+// asynchronous, as the existing entry may not yet be integrated.
+func (s *Server) existingEntry(ctx context.Context, index uint64, entry *ttessera.Entry, async bool) (*pbs.TransparencyLogEntry, error) {
+	if async {
+		safeIdx, err := tessera.NewSafeInt64(index)
+		if err != nil {
+			return nil, fmt.Errorf("invalid index: %w", err)
+		}
+		return &pbs.TransparencyLogEntry{
+			LogIndex:          safeIdx.I(),
+			CanonicalizedBody: entry.Data(),
+		}, nil
+	}
+	inclusionProof, err := s.storage.InclusionProof(ctx, index, entry.LeafHash())
+	if err != nil {
+		return nil, fmt.Errorf("proving inclusion of existing entry at index %d: %w", index, err)
+	}
+	return &pbs.TransparencyLogEntry{
+		LogIndex:          inclusionProof.LogIndex,
+		InclusionProof:    inclusionProof,
+		CanonicalizedBody: entry.Data(),
+	}, nil
+}
+
+// GetEntryProof returns the entry at the given index with an inclusion proof against the
+// latest published checkpoint. Clients that create entries asynchronously poll this
+// endpoint until the entry is covered by a checkpoint.
+func (s *Server) GetEntryProof(ctx context.Context, req *pb.EntryProofRequest) (*pbs.TransparencyLogEntry, error) {
+	if s.storage == nil {
+		return nil, status.Errorf(codes.Unimplemented, "log frozen, inclusion proofs must be computed from the log's storage")
+	}
+	tle, err := s.storage.ReadEntryWithProof(ctx, req.GetIndex())
+	if errors.As(err, &tessera.NotIntegratedError{}) {
+		return nil, notIntegratedError(ctx, req.GetIndex())
+	}
+	if errors.As(err, &tessera.IndexOutOfRangeError{}) {
+		return nil, status.Errorf(codes.OutOfRange, "entry %d has not been assigned", req.GetIndex())
+	}
+	if errors.As(err, &tessera.InclusionProofVerificationError{}) {
+		getMetrics().inclusionProofFailureCount.Inc()
+	}
+	if err != nil {
+		return nil, readError(ctx, "entry", err)
+	}
+	kv, err := kindVersion(tle.CanonicalizedBody)
+	if err != nil {
+		slog.WarnContext(ctx, "failed parsing entry", "index", req.GetIndex(), "error", err.Error())
+		return nil, status.Errorf(codes.Unknown, "failed to read entry")
+	}
+	tle.KindVersion = kv
+	tle.LogId = &v1.LogId{KeyId: s.logID}
+	return tle, nil
+}
+
+// kindVersion returns the kind and version of a canonicalized log entry.
+func kindVersion(body []byte) (*pbs.KindVersion, error) {
+	var header struct {
+		Kind       string `json:"kind"`
+		APIVersion string `json:"apiVersion"`
+	}
+	if err := json.Unmarshal(body, &header); err != nil {
+		return nil, err
+	}
+	return &pbs.KindVersion{
+		Kind:    header.Kind,
+		Version: header.APIVersion,
+	}, nil
+}
+
+// GetTile returns a full or partial Merkle tree tile, where the request path follows
+// https://c2sp.org/tlog-tiles#merkle-tree.
+func (s *Server) GetTile(ctx context.Context, req *pb.TileRequest) (*httpbody.HttpBody, error) {
+	if s.storage == nil {
+		return nil, status.Errorf(codes.Unimplemented, "log frozen, tiles must be read from the log's storage")
+	}
+	level, index, width, err := layout.ParseTileLevelIndexPartial(strconv.FormatUint(uint64(req.GetL()), 10), req.GetN())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid tile path: %v", err)
+	}
+	tile, err := s.storage.ReadTile(ctx, level, index, width)
+	if err != nil {
+		return nil, readError(ctx, "tile", err)
+	}
+	return &httpbody.HttpBody{
+		ContentType: tileContentType,
+		Data:        tile,
+	}, nil
+}
+
+// GetEntryBundle returns a full or partial bundle of log entries, where the request path follows
+// https://c2sp.org/tlog-tiles#log-entries.
+func (s *Server) GetEntryBundle(ctx context.Context, req *pb.EntryBundleRequest) (*httpbody.HttpBody, error) {
+	if s.storage == nil {
+		return nil, status.Errorf(codes.Unimplemented, "log frozen, entry bundles must be read from the log's storage")
+	}
+	index, width, err := layout.ParseTileIndexPartial(req.GetN())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid entry bundle path: %v", err)
+	}
+	bundle, err := s.storage.ReadEntryBundle(ctx, index, width)
+	if err != nil {
+		return nil, readError(ctx, "entry bundle", err)
+	}
+	return &httpbody.HttpBody{
+		ContentType: tileContentType,
+		Data:        bundle,
+	}, nil
+}
+
+// GetCheckpoint returns the latest published signed checkpoint, formatted as a
+// https://c2sp.org/signed-note.
+func (s *Server) GetCheckpoint(ctx context.Context, _ *emptypb.Empty) (*httpbody.HttpBody, error) {
+	if s.storage == nil {
+		return nil, status.Errorf(codes.Unimplemented, "log frozen, the checkpoint must be read from the log's storage")
+	}
+	checkpoint, err := s.storage.ReadCheckpoint(ctx)
+	if err != nil {
+		return nil, readError(ctx, "checkpoint", err)
+	}
+	return &httpbody.HttpBody{
+		ContentType: checkpointContentType,
+		Data:        checkpoint,
+	}, nil
+}
+
+// readError maps an error from reading the log's storage to a gRPC status.
+func readError(ctx context.Context, resource string, err error) error {
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return status.Errorf(codes.NotFound, "%s not found", resource)
+	case errors.Is(err, context.Canceled):
+		// Returns a 499 Client Closed Request
+		return status.Error(codes.Canceled, err.Error())
+	default:
+		slog.WarnContext(ctx, "failed to read from log", "resource", resource, "error", err.Error())
+		return status.Errorf(codes.Unknown, "failed to read %s", resource)
+	}
 }
 
 // Check implements the Healthcheck protocol to report the health of the service.
@@ -46,6 +46,8 @@ import (
 
 // A testing mock that wraps server.Server to Start and defer Stop a server
 type MockServer struct {
+	// Server handles requests, and defaults to a server that returns fixed responses
+	Server       rekorServer
 	gc           *GRPCConfig
 	hc           *HTTPConfig
 	wg           *sync.WaitGroup
@@ -62,7 +64,7 @@ func (ms *MockServer) Start(t *testing.T) {
 		WithHTTPPort(8080),
 	)
 
-	s := &mockRekorServer{}
+	s := ms.rekorServer()
 	shutdownFn := func(context.Context) error { return nil }
 
 	// Start the server
@@ -110,7 +112,7 @@ func (ms *MockServer) StartTLS(t *testing.T) {
 		WithGRPCTLSCredentials(certFile),
 	)
 
-	s := &mockRekorServer{}
+	s := ms.rekorServer()
 	shutdownFn := func(context.Context) error { return nil }
 
 	// Start the server
@@ -142,6 +144,13 @@ func (ms *MockServer) StartTLS(t *testing.T) {
 	}
 }
 
+func (ms *MockServer) rekorServer() rekorServer {
+	if ms.Server != nil {
+		return ms.Server
+	}
+	return &mockRekorServer{}
+}
+
 func (ms *MockServer) Stop(t *testing.T) {
 	// Simulate SIGTERM to trigger graceful shutdown
 	if err := syscall.Kill(syscall.Getpid(), syscall.SIGTERM); err != nil {
@@ -16,21 +16,33 @@ package server
 
 import (
 	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/sha512"
 	"encoding/base64"
 	"encoding/hex"
 	"fmt"
+	"os"
 	"testing"
+	"time"
 
+	"github.com/cloudflare/circl/sign/mldsa/mldsa65"
 	v1 "github.com/sigstore/protobuf-specs/gen/pb-go/common/v1"
 	"github.com/sigstore/protobuf-specs/gen/pb-go/dsse"
 	rekor_pb "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
+	"github.com/sigstore/rekor-tiles/v2/internal/admission"
 	"github.com/sigstore/rekor-tiles/v2/internal/algorithmregistry"
+	"github.com/sigstore/rekor-tiles/v2/internal/mldsa"
 	"github.com/sigstore/rekor-tiles/v2/internal/tessera"
+	"github.com/sigstore/rekor-tiles/v2/pkg/errorinfo"
 	pb "github.com/sigstore/rekor-tiles/v2/pkg/generated/protobuf"
+	"github.com/sigstore/rekor-tiles/v2/pkg/types/validation"
 	"github.com/stretchr/testify/assert"
 	ttessera "github.com/transparency-dev/tessera"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 func TestNewServer(t *testing.T) {
@@ -46,13 +58,19 @@ func TestNewServer(t *testing.T) {
 }
 
 func TestCreateEntry(t *testing.T) {
+	mldsaReq := newMLDSAHashedRekordRequest(t)
 	tests := []struct {
 		name                    string
 		req                     *pb.CreateEntryRequest
 		addFn                   func() (*rekor_pb.TransparencyLogEntry, error)
+		inclusionProofErr       error
 		clientSigningAlgorithms []string
+		returnExisting          bool
 		expectError             error
 		expectedCode            codes.Code
+		expectReason            validation.Reason
+		expectField             string
+		expectRetryDelay        time.Duration
 	}{
 		{
 			name: "valid hashedrekord",
@@ -119,6 +137,8 @@ func TestCreateEntry(t *testing.T) {
 			clientSigningAlgorithms: []string{"ecdsa-sha2-256-nistp256"},
 			expectError:             fmt.Errorf("invalid hashedrekord request"),
 			expectedCode:            codes.InvalidArgument,
+			expectReason:            validation.ReasonMissingField,
+			expectField:             "hashed_rekord_request_v002.signature.content",
 		},
 		{
 			name: "invalid dsse",
@@ -131,6 +151,8 @@ func TestCreateEntry(t *testing.T) {
 			clientSigningAlgorithms: []string{"ecdsa-sha2-256-nistp256"},
 			expectError:             fmt.Errorf("invalid dsse request"),
 			expectedCode:            codes.InvalidArgument,
+			expectReason:            validation.ReasonMissingField,
+			expectField:             "dsse_request_v002.envelope",
 		},
 		{
 			name: "context canceled",
@@ -182,6 +204,133 @@ func TestCreateEntry(t *testing.T) {
 			expectError:             fmt.Errorf("an equivalent entry already exists in the transparency log"),
 			expectedCode:            codes.AlreadyExists,
 		},
+		{
+			name: "duplicate entry returned with server-wide opt-in",
+			req: &pb.CreateEntryRequest{
+				Spec: &pb.CreateEntryRequest_HashedRekordRequestV002{
+					HashedRekordRequestV002: &pb.HashedRekordRequestV002{
+						Signature: &pb.Signature{
+							Content: b64DecodeOrDie(t, "MEYCIQC59oLS3MsCqm0xCxPOy+8FdQK4RYCZE036s3q1ECfcagIhAJ4ATXlCSdFrklKAS8No0PsAE9uLi37TCbIfRXASJTTb"),
+							Verifier: &pb.Verifier{
+								Verifier: &pb.Verifier_PublicKey{
+									PublicKey: &pb.PublicKey{
+										RawBytes: b64DecodeOrDie(t, "MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAEeLw7gX40qy1z7JUhGMAaaDITbV7p2D+C5G9xPEsy/PVAo9H0mgS4NYzpGirkXxBht+IvvL19WR1X9ANXha5ldQ=="),
+									},
+								},
+								KeyDetails: v1.PublicKeyDetails_PKIX_ECDSA_P256_SHA_256,
+							},
+						},
+						Digest: hexDecodeOrDie(t, "5b3513f580c8397212ff2c8f459c199efc0c90e4354a5f3533adf0a3fff3a530"),
+					},
+				},
+			},
+			addFn:                   func() (*rekor_pb.TransparencyLogEntry, error) { return nil, tessera.DuplicateError{} },
+			clientSigningAlgorithms: []string{"ecdsa-sha2-256-nistp256"},
+			returnExisting:          true,
+		},
+		{
+			name: "duplicate entry returned with per-request opt-in",
+			req: &pb.CreateEntryRequest{
+				Spec: &pb.CreateEntryRequest_HashedRekordRequestV002{
+					HashedRekordRequestV002: &pb.HashedRekordRequestV002{
+						Signature: &pb.Signature{
+							Content: b64DecodeOrDie(t, "MEYCIQC59oLS3MsCqm0xCxPOy+8FdQK4RYCZE036s3q1ECfcagIhAJ4ATXlCSdFrklKAS8No0PsAE9uLi37TCbIfRXASJTTb"),
+							Verifier: &pb.Verifier{
+								Verifier: &pb.Verifier_PublicKey{
+									PublicKey: &pb.PublicKey{
+										RawBytes: b64DecodeOrDie(t, "MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAEeLw7gX40qy1z7JUhGMAaaDITbV7p2D+C5G9xPEsy/PVAo9H0mgS4NYzpGirkXxBht+IvvL19WR1X9ANXha5ldQ=="),
+									},
+								},
+								KeyDetails: v1.PublicKeyDetails_PKIX_ECDSA_P256_SHA_256,
+							},
+						},
+						Digest: hexDecodeOrDie(t, "5b3513f580c8397212ff2c8f459c199efc0c90e4354a5f3533adf0a3fff3a530"),
+					},
+				},
+				ReturnExisting: true,
+			},
+			addFn:                   func() (*rekor_pb.TransparencyLogEntry, error) { return nil, tessera.DuplicateError{} },
+			clientSigningAlgorithms: []string{"ecdsa-sha2-256-nistp256"},
+		},
+		{
+			name: "duplicate entry proof failure",
+			req: &pb.CreateEntryRequest{
+				Spec: &pb.CreateEntryRequest_HashedRekordRequestV002{
+					HashedRekordRequestV002: &pb.HashedRekordRequestV002{
+						Signature: &pb.Signature{
+							Content: b64DecodeOrDie(t, "MEYCIQC59oLS3MsCqm0xCxPOy+8FdQK4RYCZE036s3q1ECfcagIhAJ4ATXlCSdFrklKAS8No0PsAE9uLi37TCbIfRXASJTTb"),
+							Verifier: &pb.Verifier{
+								Verifier: &pb.Verifier_PublicKey{
+									PublicKey: &pb.PublicKey{
+										RawBytes: b64DecodeOrDie(t, "MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAEeLw7gX40qy1z7JUhGMAaaDITbV7p2D+C5G9xPEsy/PVAo9H0mgS4NYzpGirkXxBht+IvvL19WR1X9ANXha5ldQ=="),
+									},
+								},
+								KeyDetails: v1.PublicKeyDetails_PKIX_ECDSA_P256_SHA_256,
+							},
+						},
+						Digest: hexDecodeOrDie(t, "5b3513f580c8397212ff2c8f459c199efc0c90e4354a5f3533adf0a3fff3a530"),
+					},
+				},
+				ReturnExisting: true,
+			},
+			addFn:                   func() (*rekor_pb.TransparencyLogEntry, error) { return nil, tessera.DuplicateError{} },
+			inclusionProofErr:       fmt.Errorf("checkpoint unavailable"),
+			clientSigningAlgorithms: []string{"ecdsa-sha2-256-nistp256"},
+			expectError:             fmt.Errorf("failed to integrate entry"),
+			expectedCode:            codes.Unknown,
+		},
+		{
+			name: "async hashedrekord",
+			req: &pb.CreateEntryRequest{
+				Spec: &pb.CreateEntryRequest_HashedRekordRequestV002{
+					HashedRekordRequestV002: &pb.HashedRekordRequestV002{
+						Signature: &pb.Signature{
+							Content: b64DecodeOrDie(t, "MEYCIQC59oLS3MsCqm0xCxPOy+8FdQK4RYCZE036s3q1ECfcagIhAJ4ATXlCSdFrklKAS8No0PsAE9uLi37TCbIfRXASJTTb"),
+							Verifier: &pb.Verifier{
+								Verifier: &pb.Verifier_PublicKey{
+									PublicKey: &pb.PublicKey{
+										RawBytes: b64DecodeOrDie(t, "MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAEeLw7gX40qy1z7JUhGMAaaDITbV7p2D+C5G9xPEsy/PVAo9H0mgS4NYzpGirkXxBht+IvvL19WR1X9ANXha5ldQ=="),
+									},
+								},
+								KeyDetails: v1.PublicKeyDetails_PKIX_ECDSA_P256_SHA_256,
+							},
+						},
+						Digest: hexDecodeOrDie(t, "5b3513f580c8397212ff2c8f459c199efc0c90e4354a5f3533adf0a3fff3a530"),
+					},
+				},
+				Async: true,
+			},
+			addFn: func() (*rekor_pb.TransparencyLogEntry, error) {
+				return &rekor_pb.TransparencyLogEntry{LogIndex: 1}, nil
+			},
+			clientSigningAlgorithms: []string{"ecdsa-sha2-256-nistp256"},
+		},
+		{
+			name: "async duplicate entry returned without proof",
+			req: &pb.CreateEntryRequest{
+				Spec: &pb.CreateEntryRequest_HashedRekordRequestV002{
+					HashedRekordRequestV002: &pb.HashedRekordRequestV002{
+						Signature: &pb.Signature{
+							Content: b64DecodeOrDie(t, "MEYCIQC59oLS3MsCqm0xCxPOy+8FdQK4RYCZE036s3q1ECfcagIhAJ4ATXlCSdFrklKAS8No0PsAE9uLi37TCbIfRXASJTTb"),
+							Verifier: &pb.Verifier{
+								Verifier: &pb.Verifier_PublicKey{
+									PublicKey: &pb.PublicKey{
+										RawBytes: b64DecodeOrDie(t, "MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAEeLw7gX40qy1z7JUhGMAaaDITbV7p2D+C5G9xPEsy/PVAo9H0mgS4NYzpGirkXxBht+IvvL19WR1X9ANXha5ldQ=="),
+									},
+								},
+								KeyDetails: v1.PublicKeyDetails_PKIX_ECDSA_P256_SHA_256,
+							},
+						},
+						Digest: hexDecodeOrDie(t, "5b3513f580c8397212ff2c8f459c199efc0c90e4354a5f3533adf0a3fff3a530"),
+					},
+				},
+				ReturnExisting: true,
+				Async:          true,
+			},
+			addFn:                   func() (*rekor_pb.TransparencyLogEntry, error) { return nil, tessera.DuplicateError{} },
+			inclusionProofErr:       fmt.Errorf("checkpoint unavailable"),
+			clientSigningAlgorithms: []string{"ecdsa-sha2-256-nistp256"},
+		},
 		{
 			name: "inclusion proof verification failure",
 			req: &pb.CreateEntryRequest{
@@ -232,6 +381,32 @@ func TestCreateEntry(t *testing.T) {
 			expectError:             fmt.Errorf("failed to integrate entry"),
 			expectedCode:            codes.Unknown,
 		},
+		{
+			name: "pushback",
+			req: &pb.CreateEntryRequest{
+				Spec: &pb.CreateEntryRequest_HashedRekordRequestV002{
+					HashedRekordRequestV002: &pb.HashedRekordRequestV002{
+						Signature: &pb.Signature{
+							Content: b64DecodeOrDie(t, "MEYCIQC59oLS3MsCqm0xCxPOy+8FdQK4RYCZE036s3q1ECfcagIhAJ4ATXlCSdFrklKAS8No0PsAE9uLi37TCbIfRXASJTTb"),
+							Verifier: &pb.Verifier{
+								Verifier: &pb.Verifier_PublicKey{
+									PublicKey: &pb.PublicKey{
+										RawBytes: b64DecodeOrDie(t, "MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAEeLw7gX40qy1z7JUhGMAaaDITbV7p2D+C5G9xPEsy/PVAo9H0mgS4NYzpGirkXxBht+IvvL19WR1X9ANXha5ldQ=="),
+									},
+								},
+								KeyDetails: v1.PublicKeyDetails_PKIX_ECDSA_P256_SHA_256,
+							},
+						},
+						Digest: hexDecodeOrDie(t, "5b3513f580c8397212ff2c8f459c199efc0c90e4354a5f3533adf0a3fff3a530"),
+					},
+				},
+			},
+			addFn:                   func() (*rekor_pb.TransparencyLogEntry, error) { return nil, ttessera.ErrPushback },
+			clientSigningAlgorithms: []string{"ecdsa-sha2-256-nistp256"},
+			expectError:             fmt.Errorf("reached max pushback; retry"),
+			expectedCode:            codes.Unavailable,
+			expectRetryDelay:        DefaultPushbackRetryAfter,
+		},
 		{
 			name: "hashedrekord signed with disallowed algorithm",
 			req: &pb.CreateEntryRequest{
@@ -258,16 +433,33 @@ MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAEeLw7gX40qy1z7JUhGMAaaDITbV7p
 			clientSigningAlgorithms: []string{"rsa-sign-pkcs1-4096-sha256"},
 			expectError:             fmt.Errorf("invalid hashedrekord request"),
 			expectedCode:            codes.InvalidArgument,
+			expectReason:            validation.ReasonInvalidVerifier,
+			expectField:             "hashed_rekord_request_v002.signature.verifier",
+		},
+		{
+			name:                    "valid ml-dsa hashedrekord",
+			req:                     mldsaReq,
+			addFn:                   func() (*rekor_pb.TransparencyLogEntry, error) { return &rekor_pb.TransparencyLogEntry{}, nil },
+			clientSigningAlgorithms: []string{"ecdsa-sha2-256-nistp256", "ml-dsa-65"},
+		},
+		{
+			name:         "ml-dsa hashedrekord with default algorithms",
+			req:          mldsaReq,
+			addFn:        func() (*rekor_pb.TransparencyLogEntry, error) { return &rekor_pb.TransparencyLogEntry{}, nil },
+			expectError:  fmt.Errorf("invalid hashedrekord request"),
+			expectedCode: codes.InvalidArgument,
+			expectReason: validation.ReasonUnsupportedAlgorithm,
+			expectField:  "hashed_rekord_request_v002.signature.verifier.key_details",
 		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			storage := &mockStorage{addFn: test.addFn}
+			storage := &mockStorage{addFn: test.addFn, inclusionProofErr: test.inclusionProofErr}
 			algReg, err := algorithmregistry.AlgorithmRegistry(test.clientSigningAlgorithms)
 			if err != nil {
 				t.Fatal(err)
 			}
-			server := NewServer(storage, false, algReg, []byte{1})
+			server := NewServer(storage, false, algReg, []byte{1}, WithReturnExisting(test.returnExisting))
 			gotTle, gotErr := server.CreateEntry(context.Background(), test.req)
 			if test.expectError == nil {
 				assert.NoError(t, gotErr)
@@ -280,21 +472,429 @@ MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAEeLw7gX40qy1z7JUhGMAaaDITbV7p
 				assert.True(t, ok)
 				assert.Equal(t, s.Code(), test.expectedCode)
 				assert.ErrorContains(t, gotErr, test.expectError.Error())
+				if test.expectReason != "" {
+					assertValidationDetails(t, s, test.expectReason, test.expectField)
+				}
+				if test.expectRetryDelay != 0 {
+					if assert.Len(t, s.Details(), 2) {
+						errorInfo, ok := s.Details()[0].(*errdetails.ErrorInfo)
+						if assert.True(t, ok) {
+							assert.Equal(t, errorinfo.ReasonPushback, errorInfo.GetReason())
+							assert.Equal(t, errorinfo.LogDomain, errorInfo.GetDomain())
+						}
+						retryInfo, ok := s.Details()[1].(*errdetails.RetryInfo)
+						assert.True(t, ok)
+						assert.Equal(t, test.expectRetryDelay, retryInfo.GetRetryDelay().AsDuration())
+					}
+				}
 			}
 		})
 	}
 }
 
+// newMLDSAHashedRekordRequest returns a request for a hashedrekord entry signed with
+// a generated ML-DSA-65 key.
+func newMLDSAHashedRekordRequest(t *testing.T) *pb.CreateEntryRequest {
+	pub, priv, err := mldsa65.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := mldsa.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := sha512.Sum512([]byte("firmware"))
+	sig, err := priv.Sign(rand.Reader, digest[:], crypto.Hash(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &pb.CreateEntryRequest{
+		Spec: &pb.CreateEntryRequest_HashedRekordRequestV002{
+			HashedRekordRequestV002: &pb.HashedRekordRequestV002{
+				Signature: &pb.Signature{
+					Content: sig,
+					Verifier: &pb.Verifier{
+						Verifier:   &pb.Verifier_PublicKey{PublicKey: &pb.PublicKey{RawBytes: der}},
+						KeyDetails: v1.PublicKeyDetails_ML_DSA_65,
+					},
+				},
+				Digest: digest[:],
+			},
+		},
+	}
+}
+
+type failingPolicy struct{}
+
+func (failingPolicy) Admit(context.Context, *admission.Input) error {
+	return fmt.Errorf("policy unavailable")
+}
+
+func TestCreateEntryAdmissionPolicy(t *testing.T) {
+	req := &pb.CreateEntryRequest{
+		Spec: &pb.CreateEntryRequest_DsseRequestV002{
+			DsseRequestV002: &pb.DSSERequestV002{
+				Envelope: &dsse.Envelope{
+					Payload:     b64DecodeOrDie(t, "cGF5bG9hZA=="),
+					PayloadType: "application/vnd.in-toto+json",
+					Signatures: []*dsse.Signature{
+						{
+							Sig: b64DecodeOrDie(t, "MEUCIQCSWas1Y9bI7aDNrBdHlzrFH8ch7B7IM+pJK86mtjkbJAIgaeCltz6vs20DP2sJ7IBihvcrdqGn3ivuV/KNPlMOetk="),
+						},
+					},
+				},
+				Verifiers: []*pb.Verifier{
+					{
+						Verifier: &pb.Verifier_PublicKey{
+							PublicKey: &pb.PublicKey{
+								RawBytes: b64DecodeOrDie(t, "MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAE850nB+WrwXzivt7yFbhFKw/8M2paqSTHiQhkA4/0ZAsJtmzn/v4HdeZKTCQcsHq5IwM/LtbmEdv9ChO9M3cg9g=="),
+							},
+						},
+						KeyDetails: v1.PublicKeyDetails_PKIX_ECDSA_P256_SHA_256,
+					},
+				},
+			},
+		},
+	}
+	allowInToto, err := admission.NewCELPolicy([]admission.Rule{{
+		Name:       "payload-type",
+		Expression: `request.payloadType == "application/vnd.in-toto+json"`,
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	denyPublicKeys, err := admission.NewCELPolicy([]admission.Rule{{
+		Name:       "certificates-only",
+		Expression: `identities.all(i, i.certificate)`,
+		Message:    "entries must be signed with a certificate",
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		policy       admission.Policy
+		dryRun       bool
+		expectedCode codes.Code
+		expectError  string
+	}{
+		{
+			name:   "admitted",
+			policy: allowInToto,
+		},
+		{
+			name:         "denied",
+			policy:       denyPublicKeys,
+			expectedCode: codes.PermissionDenied,
+			expectError:  `denied by admission rule "certificates-only": entries must be signed with a certificate`,
+		},
+		{
+			name:   "dry run",
+			policy: denyPublicKeys,
+			dryRun: true,
+		},
+		{
+			name:         "policy error",
+			policy:       failingPolicy{},
+			expectedCode: codes.Internal,
+			expectError:  "failed evaluating admission policy",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			storage := &mockStorage{addFn: func() (*rekor_pb.TransparencyLogEntry, error) { return &rekor_pb.TransparencyLogEntry{}, nil }}
+			algReg, err := algorithmregistry.AlgorithmRegistry([]string{"ecdsa-sha2-256-nistp256"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			server := NewServer(storage, false, algReg, []byte{1}, WithAdmissionPolicy(test.policy, test.dryRun))
+			gotTle, gotErr := server.CreateEntry(context.Background(), req)
+			if test.expectError == "" {
+				assert.NoError(t, gotErr)
+				assert.NotNil(t, gotTle)
+				return
+			}
+			s, ok := status.FromError(gotErr)
+			assert.True(t, ok)
+			assert.Equal(t, test.expectedCode, s.Code())
+			assert.ErrorContains(t, gotErr, test.expectError)
+			if test.expectedCode == codes.PermissionDenied && assert.Len(t, s.Details(), 1) {
+				info, ok := s.Details()[0].(*errdetails.ErrorInfo)
+				if assert.True(t, ok) {
+					assert.Equal(t, errorinfo.ReasonAdmissionDenied, info.GetReason())
+					assert.Equal(t, errorinfo.AdmissionDomain, info.GetDomain())
+					assert.Equal(t, "certificates-only", info.GetMetadata()["rule"])
+				}
+			}
+		})
+	}
+}
+
+func TestGetTile(t *testing.T) {
+	tests := []struct {
+		name         string
+		req          *pb.TileRequest
+		expectData   []byte
+		expectedCode codes.Code
+	}{
+		{
+			name:       "full tile",
+			req:        &pb.TileRequest{L: 1, N: "x123/456"},
+			expectData: []byte("tile:1,123456,0"),
+		},
+		{
+			name:       "partial tile",
+			req:        &pb.TileRequest{L: 0, N: "002.p/3"},
+			expectData: []byte("tile:0,2,3"),
+		},
+		{
+			name:         "invalid level",
+			req:          &pb.TileRequest{L: 64, N: "002"},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name:         "invalid index",
+			req:          &pb.TileRequest{L: 0, N: "x12/3"},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name:         "invalid partial width",
+			req:          &pb.TileRequest{L: 0, N: "002.p/256"},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name:         "tile not found",
+			req:          &pb.TileRequest{L: 0, N: "999"},
+			expectedCode: codes.NotFound,
+		},
+		{
+			name:         "storage failure",
+			req:          &pb.TileRequest{L: 0, N: "500"},
+			expectedCode: codes.Unknown,
+		},
+	}
+	server := NewServer(&mockStorage{}, false, nil, []byte{1})
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, gotErr := server.GetTile(context.Background(), test.req)
+			if test.expectedCode == codes.OK {
+				assert.NoError(t, gotErr)
+				assert.Equal(t, "application/octet-stream", got.ContentType)
+				assert.Equal(t, test.expectData, got.Data)
+				return
+			}
+			assert.Equal(t, test.expectedCode, status.Code(gotErr))
+		})
+	}
+}
+
+func TestGetEntryBundle(t *testing.T) {
+	tests := []struct {
+		name         string
+		req          *pb.EntryBundleRequest
+		expectData   []byte
+		expectedCode codes.Code
+	}{
+		{
+			name:       "full bundle",
+			req:        &pb.EntryBundleRequest{N: "x001/234"},
+			expectData: []byte("entries:1234,0"),
+		},
+		{
+			name:       "partial bundle",
+			req:        &pb.EntryBundleRequest{N: "001.p/2"},
+			expectData: []byte("entries:1,2"),
+		},
+		{
+			name:         "invalid index",
+			req:          &pb.EntryBundleRequest{N: "x1.p/2/3"},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name:         "bundle not found",
+			req:          &pb.EntryBundleRequest{N: "999"},
+			expectedCode: codes.NotFound,
+		},
+	}
+	server := NewServer(&mockStorage{}, false, nil, []byte{1})
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, gotErr := server.GetEntryBundle(context.Background(), test.req)
+			if test.expectedCode == codes.OK {
+				assert.NoError(t, gotErr)
+				assert.Equal(t, "application/octet-stream", got.ContentType)
+				assert.Equal(t, test.expectData, got.Data)
+				return
+			}
+			assert.Equal(t, test.expectedCode, status.Code(gotErr))
+		})
+	}
+}
+
+func TestGetCheckpoint(t *testing.T) {
+	server := NewServer(&mockStorage{}, false, nil, []byte{1})
+	got, err := server.GetCheckpoint(context.Background(), &emptypb.Empty{})
+	assert.NoError(t, err)
+	assert.Equal(t, "text/plain; charset=utf-8", got.ContentType)
+	assert.Equal(t, []byte("checkpoint"), got.Data)
+
+	server = NewServer(&mockStorage{readCheckpointErr: os.ErrNotExist}, false, nil, []byte{1})
+	_, err = server.GetCheckpoint(context.Background(), &emptypb.Empty{})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	server = NewServer(nil, true, nil, []byte{1})
+	_, err = server.GetCheckpoint(context.Background(), &emptypb.Empty{})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func TestGetEntryProof(t *testing.T) {
+	tests := []struct {
+		name         string
+		storage      *mockStorage
+		index        uint64
+		expectedCode codes.Code
+		expectReason string
+	}{
+		{
+			name:    "integrated entry",
+			storage: &mockStorage{},
+			index:   3,
+		},
+		{
+			name:         "entry not yet integrated",
+			storage:      &mockStorage{readEntryErr: tessera.NotIntegratedError{}},
+			index:        3,
+			expectedCode: codes.NotFound,
+			expectReason: errorinfo.ReasonNotIntegrated,
+		},
+		{
+			name:         "index not assigned",
+			storage:      &mockStorage{readEntryErr: tessera.IndexOutOfRangeError{}},
+			index:        3,
+			expectedCode: codes.OutOfRange,
+		},
+		{
+			name:         "entry bundle not found",
+			storage:      &mockStorage{readEntryErr: fmt.Errorf("reading entry bundle: %w", os.ErrNotExist)},
+			index:        3,
+			expectedCode: codes.NotFound,
+		},
+		{
+			name:         "inclusion proof verification failure",
+			storage:      &mockStorage{readEntryErr: tessera.InclusionProofVerificationError{}},
+			index:        3,
+			expectedCode: codes.Unknown,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := NewServer(test.storage, false, nil, []byte{1})
+			got, gotErr := server.GetEntryProof(context.Background(), &pb.EntryProofRequest{Index: test.index})
+			if test.expectedCode != codes.OK {
+				s := status.Convert(gotErr)
+				assert.Equal(t, test.expectedCode, s.Code())
+				if test.expectReason == "" {
+					assert.Empty(t, s.Details())
+				} else if assert.Len(t, s.Details(), 1) {
+					info, ok := s.Details()[0].(*errdetails.ErrorInfo)
+					if assert.True(t, ok) {
+						assert.Equal(t, test.expectReason, info.GetReason())
+						assert.Equal(t, errorinfo.LogDomain, info.GetDomain())
+					}
+				}
+				return
+			}
+			assert.NoError(t, gotErr)
+			assert.Equal(t, int64(test.index), got.LogIndex)
+			assert.Equal(t, &rekor_pb.KindVersion{Kind: "hashedrekord", Version: "0.0.2"}, got.KindVersion)
+			assert.Equal(t, []byte{1}, got.LogId.KeyId)
+			assert.NotNil(t, got.InclusionProof)
+		})
+	}
+
+	server := NewServer(nil, true, nil, []byte{1})
+	_, err := server.GetEntryProof(context.Background(), &pb.EntryProofRequest{Index: 0})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
 type mockStorage struct {
-	addFn func() (*rekor_pb.TransparencyLogEntry, error)
+	addFn             func() (*rekor_pb.TransparencyLogEntry, error)
+	readCheckpointErr error
+	inclusionProofErr error
+	readEntryErr      error
 }
 
 func (s *mockStorage) Add(_ context.Context, _ *ttessera.Entry) (*rekor_pb.TransparencyLogEntry, error) {
 	return s.addFn()
 }
 
-func (s *mockStorage) ReadTile(_ context.Context, _, _ uint64, _ uint8) ([]byte, error) {
-	return nil, nil
+func (s *mockStorage) AddAsync(_ context.Context, _ *ttessera.Entry) (*rekor_pb.TransparencyLogEntry, error) {
+	return s.addFn()
+}
+
+func (s *mockStorage) ReadEntryWithProof(_ context.Context, index uint64) (*rekor_pb.TransparencyLogEntry, error) {
+	if s.readEntryErr != nil {
+		return nil, s.readEntryErr
+	}
+	return &rekor_pb.TransparencyLogEntry{
+		LogIndex:          int64(index),
+		InclusionProof:    &rekor_pb.InclusionProof{LogIndex: int64(index), TreeSize: int64(index) + 1},
+		CanonicalizedBody: []byte(`{"apiVersion":"0.0.2","kind":"hashedrekord","spec":{}}`),
+	}, nil
+}
+
+func (s *mockStorage) ReadTile(_ context.Context, level, index uint64, p uint8) ([]byte, error) {
+	switch index {
+	case 999:
+		return nil, fmt.Errorf("reading tile: %w", os.ErrNotExist)
+	case 500:
+		return nil, fmt.Errorf("storage unavailable")
+	}
+	return []byte(fmt.Sprintf("tile:%d,%d,%d", level, index, p)), nil
+}
+
+func (s *mockStorage) ReadEntryBundle(_ context.Context, index uint64, p uint8) ([]byte, error) {
+	if index == 999 {
+		return nil, fmt.Errorf("reading entry bundle: %w", os.ErrNotExist)
+	}
+	return []byte(fmt.Sprintf("entries:%d,%d", index, p)), nil
+}
+
+func (s *mockStorage) ReadCheckpoint(_ context.Context) ([]byte, error) {
+	if s.readCheckpointErr != nil {
+		return nil, s.readCheckpointErr
+	}
+	return []byte("checkpoint"), nil
+}
+
+func (s *mockStorage) InclusionProof(_ context.Context, index uint64, _ []byte) (*rekor_pb.InclusionProof, error) {
+	if s.inclusionProofErr != nil {
+		return nil, s.inclusionProofErr
+	}
+	return &rekor_pb.InclusionProof{LogIndex: int64(index), TreeSize: int64(index) + 1}, nil
+}
+
+func assertValidationDetails(t *testing.T, s *status.Status, reason validation.Reason, field string) {
+	t.Helper()
+	var gotInfo *errdetails.ErrorInfo
+	var gotBadRequest *errdetails.BadRequest
+	for _, d := range s.Details() {
+		switch d := d.(type) {
+		case *errdetails.ErrorInfo:
+			gotInfo = d
+		case *errdetails.BadRequest:
+			gotBadRequest = d
+		}
+	}
+	if assert.NotNil(t, gotInfo) {
+		assert.Equal(t, string(reason), gotInfo.Reason)
+		assert.Equal(t, validation.Domain, gotInfo.Domain)
+	}
+	if assert.NotNil(t, gotBadRequest) && assert.Len(t, gotBadRequest.FieldViolations, 1) {
+		assert.Equal(t, field, gotBadRequest.FieldViolations[0].Field)
+		assert.NotEmpty(t, gotBadRequest.FieldViolations[0].Description)
+	}
 }
 
 func hexDecodeOrDie(t *testing.T, hash string) []byte {
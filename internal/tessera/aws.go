@@ -0,0 +1,89 @@
+//
+// Copyright 2025 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tessera
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/go-sql-driver/mysql"
+	"github.com/transparency-dev/tessera"
+	"github.com/transparency-dev/tessera/storage/aws"
+	antispam "github.com/transparency-dev/tessera/storage/aws/antispam"
+)
+
+// NewAWSDriver returns an AWS Tessera Driver for the given S3 bucket and MySQL DSN.
+// If s3Endpoint is set, the S3 client is configured for a custom S3-compatible service
+// such as MinIO, using path-style addressing and credentials from the default AWS
+// credential chain. The HTTP client is used to contact witnesses.
+func NewAWSDriver(ctx context.Context, bucket, mysqlDSN, s3Endpoint string, maxOpenConns, maxIdleConns int, httpClient *http.Client) (tessera.Driver, error) {
+	cfg := aws.Config{
+		Bucket:       bucket,
+		DSN:          mysqlDSN,
+		MaxOpenConns: maxOpenConns,
+		MaxIdleConns: maxIdleConns,
+		HTTPClient:   httpClient,
+	}
+	if s3Endpoint != "" {
+		sdkConfig, err := config.LoadDefaultConfig(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("loading AWS configuration: %w", err)
+		}
+		cfg.SDKConfig = &sdkConfig
+		cfg.S3Options = func(o *s3.Options) {
+			o.BaseEndpoint = awssdk.String(s3Endpoint)
+			o.UsePathStyle = true
+		}
+	}
+	driver, err := aws.New(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("getting tessera AWS driver: %w", err)
+	}
+	return driver, nil
+}
+
+// NewAWSAntispam initializes a MySQL database to store recent entries. The database
+// must already exist and is named after the sequencer database with an _antispam suffix.
+func NewAWSAntispam(ctx context.Context, mysqlDSN string, maxBatchSize, pushbackThreshold uint, maxOpenConns, maxIdleConns int) (tessera.Antispam, error) {
+	dsn, err := antispamDSN(mysqlDSN)
+	if err != nil {
+		return nil, err
+	}
+	asOpts := antispam.AntispamOpts{
+		MaxBatchSize:      maxBatchSize,
+		PushbackThreshold: pushbackThreshold,
+		MaxOpenConns:      maxOpenConns,
+		MaxIdleConns:      maxIdleConns,
+	}
+	return antispam.NewAntispam(ctx, dsn, asOpts)
+}
+
+// antispamDSN returns the DSN of the antispam database for the given sequencer database DSN.
+func antispamDSN(mysqlDSN string) (string, error) {
+	cfg, err := mysql.ParseDSN(mysqlDSN)
+	if err != nil {
+		return "", fmt.Errorf("parsing MySQL DSN: %w", err)
+	}
+	if cfg.DBName == "" {
+		return "", fmt.Errorf("MySQL DSN must include a database name")
+	}
+	cfg.DBName = fmt.Sprintf("%s_antispam", cfg.DBName)
+	return cfg.FormatDSN(), nil
+}
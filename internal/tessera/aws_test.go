@@ -0,0 +1,63 @@
+//
+// Copyright 2025 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tessera
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAntispamDSN(t *testing.T) {
+	tests := []struct {
+		name      string
+		dsn       string
+		expectDSN string
+		expectErr string
+	}{
+		{
+			name:      "valid DSN",
+			dsn:       "rekor:secret@tcp(mysql:3306)/sequencer",
+			expectDSN: "rekor:secret@tcp(mysql:3306)/sequencer_antispam",
+		},
+		{
+			name:      "valid DSN with parameters",
+			dsn:       "rekor:secret@tcp(mysql:3306)/sequencer?parseTime=true",
+			expectDSN: "rekor:secret@tcp(mysql:3306)/sequencer_antispam?parseTime=true",
+		},
+		{
+			name:      "missing database",
+			dsn:       "rekor:secret@tcp(mysql:3306)/",
+			expectErr: "MySQL DSN must include a database name",
+		},
+		{
+			name:      "invalid DSN",
+			dsn:       "not a dsn",
+			expectErr: "parsing MySQL DSN",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := antispamDSN(test.dsn)
+			if test.expectErr != "" {
+				assert.ErrorContains(t, err, test.expectErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectDSN, got)
+		})
+	}
+}
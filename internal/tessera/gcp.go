@@ -18,6 +18,7 @@ package tessera
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"runtime"
 
 	"cloud.google.com/go/spanner"
@@ -30,7 +31,8 @@ import (
 )
 
 // NewGCPDriver returns a GCP Tessera Driver for the given bucket and spanner URI.
-func NewGCPDriver(ctx context.Context, bucket, spannerDB, hostname string) (tessera.Driver, error) {
+// The HTTP client is used to contact witnesses.
+func NewGCPDriver(ctx context.Context, bucket, spannerDB, hostname string, httpClient *http.Client) (tessera.Driver, error) {
 	userAgent := userAgent(hostname)
 	gcsClient, err := gcs.NewClient(ctx, gcs.WithJSONReads(), option.WithUserAgent(userAgent))
 	if err != nil {
@@ -45,6 +47,7 @@ func NewGCPDriver(ctx context.Context, bucket, spannerDB, hostname string) (tess
 		Spanner:       spannerDB,
 		GCSClient:     gcsClient,
 		SpannerClient: spannerClient,
+		HTTPClient:    httpClient,
 	}
 	driver, err := gcp.New(ctx, cfg)
 	if err != nil {
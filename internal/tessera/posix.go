@@ -0,0 +1,52 @@
+//
+// Copyright 2025 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tessera
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path/filepath"
+
+	"github.com/transparency-dev/tessera"
+	"github.com/transparency-dev/tessera/storage/posix"
+	antispam "github.com/transparency-dev/tessera/storage/posix/antispam"
+)
+
+// NewPOSIXDriver returns a POSIX filesystem Tessera Driver storing the log in the given directory.
+// The HTTP client is used to contact witnesses.
+func NewPOSIXDriver(ctx context.Context, storageDir string, httpClient *http.Client) (tessera.Driver, error) {
+	cfg := posix.Config{
+		Path:       storageDir,
+		HTTPClient: httpClient,
+	}
+	driver, err := posix.New(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("getting tessera POSIX driver: %w", err)
+	}
+	return driver, nil
+}
+
+// NewPOSIXAntispam initializes a Badger database to store recent entries. The database is
+// stored alongside, rather than within, the log directory, so that it is not served with the log.
+func NewPOSIXAntispam(ctx context.Context, storageDir string, maxBatchSize, pushbackThreshold uint) (tessera.Antispam, error) {
+	asOpts := antispam.AntispamOpts{
+		MaxBatchSize:      maxBatchSize,
+		PushbackThreshold: pushbackThreshold,
+	}
+	dbPath := fmt.Sprintf("%s-antispam", filepath.Clean(storageDir))
+	return antispam.NewAntispam(ctx, dbPath, asOpts)
+}
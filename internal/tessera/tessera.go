@@ -19,6 +19,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
 	rekor_pb "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
@@ -28,6 +29,8 @@ import (
 	"github.com/transparency-dev/merkle/proof"
 	"github.com/transparency-dev/merkle/rfc6962"
 	"github.com/transparency-dev/tessera"
+	"github.com/transparency-dev/tessera/api"
+	"github.com/transparency-dev/tessera/api/layout"
 	"github.com/transparency-dev/tessera/client"
 )
 
@@ -46,6 +49,31 @@ func (e DuplicateError) Error() string {
 	return fmt.Sprintf("an equivalent entry already exists in the transparency log with index %d", e.index)
 }
 
+// Index returns the log index of the existing entry.
+func (e DuplicateError) Index() uint64 {
+	return e.index
+}
+
+// NotIntegratedError is returned when an entry is not yet covered by the latest published checkpoint.
+type NotIntegratedError struct {
+	index    uint64
+	treeSize uint64
+}
+
+func (e NotIntegratedError) Error() string {
+	return fmt.Sprintf("index %d is not covered by checkpoint of size %d", e.index, e.treeSize)
+}
+
+// IndexOutOfRangeError is returned when an index has not been assigned to any entry.
+type IndexOutOfRangeError struct {
+	index     uint64
+	nextIndex uint64
+}
+
+func (e IndexOutOfRangeError) Error() string {
+	return fmt.Sprintf("index %d has not been assigned, next index is %d", e.index, e.nextIndex)
+}
+
 type InclusionProofVerificationError struct {
 	index uint64
 	err   error
@@ -55,17 +83,25 @@ func (e InclusionProofVerificationError) Error() string {
 	return fmt.Sprintf("verifying inclusion proof for index %d: %v", e.index, e.err)
 }
 
-// Storage provides the functions to add entries to a Tessera log.
+// Storage provides the functions to add entries to and read from a Tessera log.
 type Storage interface {
 	Add(ctx context.Context, entry *tessera.Entry) (*rekor_pb.TransparencyLogEntry, error)
+	AddAsync(ctx context.Context, entry *tessera.Entry) (*rekor_pb.TransparencyLogEntry, error)
+	ReadEntryWithProof(ctx context.Context, index uint64) (*rekor_pb.TransparencyLogEntry, error)
 	ReadTile(ctx context.Context, level, index uint64, p uint8) ([]byte, error)
+	ReadEntryBundle(ctx context.Context, index uint64, p uint8) ([]byte, error)
+	ReadCheckpoint(ctx context.Context) ([]byte, error)
+	InclusionProof(ctx context.Context, index uint64, leafHash []byte) (*rekor_pb.InclusionProof, error)
 }
 
 type storage struct {
-	origin     string
-	awaiter    *tessera.PublicationAwaiter
-	addFn      tessera.AddFn
-	readTileFn client.TileFetcherFunc
+	origin            string
+	awaiter           *tessera.PublicationAwaiter
+	addFn             tessera.AddFn
+	readTileFn        client.TileFetcherFunc
+	readEntryBundleFn client.EntryBundleFetcherFunc
+	readCheckpointFn  client.CheckpointFetcherFunc
+	nextIndexFn       func(context.Context) (uint64, error)
 }
 
 // NewAppendOptions initializes the Tessera append options with a checkpoint signer, which is the only non-optional append option.
@@ -106,17 +142,44 @@ type DriverConfiguration struct {
 	GCPBucket    string
 	GCPSpannerDB string
 
+	// AWS configuration
+	AWSBucket            string
+	AWSMySQLDSN          string
+	AWSS3Endpoint        string
+	AWSMySQLMaxOpenConns int
+	AWSMySQLMaxIdleConns int
+
+	// POSIX configuration
+	StorageDir string
+
 	// Antispam configuration
 	PersistentAntispam  bool
 	ASMaxBatchSize      uint
 	ASPushbackThreshold uint
+
+	// Witnessing configuration
+	WitnessTimeout time.Duration
 }
 
 // NewDriver creates a Tessera driver and optional persistent antispam for a given storage backend.
 func NewDriver(ctx context.Context, config DriverConfiguration) (tessera.Driver, tessera.Antispam, error) {
+	httpClient := witnessHTTPClient(config.WitnessTimeout)
+	var backends []string
+	if config.GCPBucket != "" || config.GCPSpannerDB != "" {
+		backends = append(backends, "GCP")
+	}
+	if config.AWSBucket != "" || config.AWSMySQLDSN != "" {
+		backends = append(backends, "AWS")
+	}
+	if config.StorageDir != "" {
+		backends = append(backends, "POSIX")
+	}
+	if len(backends) > 1 {
+		return nil, nil, fmt.Errorf("flags provided for conflicting Tessera drivers: %s", strings.Join(backends, ", "))
+	}
 	switch {
 	case config.GCPBucket != "" && config.GCPSpannerDB != "":
-		driver, err := NewGCPDriver(ctx, config.GCPBucket, config.GCPSpannerDB, config.Hostname)
+		driver, err := NewGCPDriver(ctx, config.GCPBucket, config.GCPSpannerDB, config.Hostname, httpClient)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to initialize GCP driver: %v", err.Error())
 		}
@@ -129,6 +192,40 @@ func NewDriver(ctx context.Context, config DriverConfiguration) (tessera.Driver,
 			persistentAntispam = as
 		}
 		return driver, persistentAntispam, nil
+	case config.AWSBucket != "" && config.AWSMySQLDSN != "":
+		driver, err := NewAWSDriver(ctx, config.AWSBucket, config.AWSMySQLDSN, config.AWSS3Endpoint, config.AWSMySQLMaxOpenConns, config.AWSMySQLMaxIdleConns, httpClient)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to initialize AWS driver: %v", err.Error())
+		}
+		var persistentAntispam tessera.Antispam
+		if config.PersistentAntispam {
+			as, err := NewAWSAntispam(ctx, config.AWSMySQLDSN, config.ASMaxBatchSize, config.ASPushbackThreshold, config.AWSMySQLMaxOpenConns, config.AWSMySQLMaxIdleConns)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to initialize AWS antispam: %v", err.Error())
+			}
+			persistentAntispam = as
+		}
+		return driver, persistentAntispam, nil
+	case config.AWSBucket != "" || config.AWSMySQLDSN != "":
+		missing := "--aws-mysql-dsn"
+		if config.AWSBucket == "" {
+			missing = "--aws-bucket"
+		}
+		return nil, nil, fmt.Errorf("AWS driver requires both --aws-bucket and --aws-mysql-dsn, missing %s", missing)
+	case config.StorageDir != "":
+		driver, err := NewPOSIXDriver(ctx, config.StorageDir, httpClient)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to initialize POSIX driver: %v", err.Error())
+		}
+		var persistentAntispam tessera.Antispam
+		if config.PersistentAntispam {
+			as, err := NewPOSIXAntispam(ctx, config.StorageDir, config.ASMaxBatchSize, config.ASPushbackThreshold)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to initialize POSIX antispam: %v", err.Error())
+			}
+			persistentAntispam = as
+		}
+		return driver, persistentAntispam, nil
 	default:
 		return nil, nil, fmt.Errorf("no flags provided to initialize Tessera driver")
 	}
@@ -144,10 +241,13 @@ func NewStorage(ctx context.Context, origin string, driver tessera.Driver, appen
 	slog.Info("starting Tessera sequencer")
 	awaiter := tessera.NewPublicationAwaiter(ctx, reader.ReadCheckpoint, 1*time.Second)
 	return &storage{
-		origin:     origin,
-		awaiter:    awaiter,
-		addFn:      appender.Add,
-		readTileFn: reader.ReadTile,
+		origin:            origin,
+		awaiter:           awaiter,
+		addFn:             appender.Add,
+		readTileFn:        reader.ReadTile,
+		readEntryBundleFn: reader.ReadEntryBundle,
+		readCheckpointFn:  reader.ReadCheckpoint,
+		nextIndexFn:       reader.NextIndex,
 	}, shutdown, nil
 }
 
@@ -172,6 +272,88 @@ func (s *storage) Add(ctx context.Context, entry *tessera.Entry) (*rekor_pb.Tran
 	}, nil
 }
 
+// AddAsync adds a Tessera entry to the log and waits only for it to be assigned a log index.
+// The returned TransparencyLogEntry does not contain an inclusion proof, which can be
+// fetched with ReadEntryWithProof once a checkpoint covering the entry is published.
+func (s *storage) AddAsync(ctx context.Context, entry *tessera.Entry) (*rekor_pb.TransparencyLogEntry, error) {
+	idx, err := s.addFn(ctx, entry)()
+	if err != nil {
+		return nil, fmt.Errorf("add entry: %w", err)
+	}
+	safeIdx, err := NewSafeInt64(idx.Index)
+	if err != nil {
+		return nil, fmt.Errorf("invalid index: %w", err)
+	}
+	if idx.IsDup {
+		return nil, DuplicateError{index: idx.Index}
+	}
+	return &rekor_pb.TransparencyLogEntry{
+		LogIndex:          safeIdx.I(),
+		CanonicalizedBody: entry.Data(),
+	}, nil
+}
+
+// notIntegratedError returns an IndexOutOfRangeError if the index beyond the checkpoint
+// has not been assigned yet, and a NotIntegratedError otherwise.
+func (s *storage) notIntegratedError(ctx context.Context, index, treeSize uint64) error {
+	if s.nextIndexFn == nil {
+		return NotIntegratedError{index: index, treeSize: treeSize}
+	}
+	nextIndex, err := s.nextIndexFn(ctx)
+	if err != nil {
+		return fmt.Errorf("reading next index: %w", err)
+	}
+	if index >= nextIndex {
+		return IndexOutOfRangeError{index: index, nextIndex: nextIndex}
+	}
+	return NotIntegratedError{index: index, treeSize: treeSize}
+}
+
+// ReadEntryWithProof reads the entry at the given index from its entry bundle and returns
+// it with an inclusion proof against the latest published checkpoint. Returns a
+// NotIntegratedError if the checkpoint does not yet cover the entry, or an
+// IndexOutOfRangeError if no entry has been assigned the index.
+func (s *storage) ReadEntryWithProof(ctx context.Context, index uint64) (*rekor_pb.TransparencyLogEntry, error) {
+	safeIdx, err := NewSafeInt64(index)
+	if err != nil {
+		return nil, fmt.Errorf("invalid index: %w", err)
+	}
+	checkpointBody, err := s.ReadCheckpoint(ctx)
+	if err != nil {
+		return nil, err
+	}
+	checkpoint, err := unmarshalCheckpoint(checkpointBody)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshalling checkpoint: %w", err)
+	}
+	if index >= checkpoint.Size {
+		return nil, s.notIntegratedError(ctx, index, checkpoint.Size)
+	}
+	bundleIndex := index / layout.EntryBundleWidth
+	rawBundle, err := s.ReadEntryBundle(ctx, bundleIndex, layout.PartialTileSize(0, bundleIndex, checkpoint.Size))
+	if err != nil {
+		return nil, err
+	}
+	bundle := api.EntryBundle{}
+	if err := bundle.UnmarshalText(rawBundle); err != nil {
+		return nil, fmt.Errorf("parsing entry bundle %d: %w", bundleIndex, err)
+	}
+	offset := index % layout.EntryBundleWidth
+	if offset >= uint64(len(bundle.Entries)) {
+		return nil, fmt.Errorf("entry bundle %d contains %d entries, missing index %d", bundleIndex, len(bundle.Entries), index)
+	}
+	body := bundle.Entries[offset]
+	inclusionProof, err := s.buildProof(ctx, safeIdx, checkpointBody, rfc6962.DefaultHasher.HashLeaf(body))
+	if err != nil {
+		return nil, fmt.Errorf("building inclusion proof: %w", err)
+	}
+	return &rekor_pb.TransparencyLogEntry{
+		LogIndex:          safeIdx.I(),
+		InclusionProof:    inclusionProof,
+		CanonicalizedBody: body,
+	}, nil
+}
+
 // ReadTile looks up the tile at the given level, index within the level, and
 // width of the tile if partial, and returns the raw bytes of the tile.
 func (s *storage) ReadTile(ctx context.Context, level, index uint64, p uint8) ([]byte, error) {
@@ -182,6 +364,43 @@ func (s *storage) ReadTile(ctx context.Context, level, index uint64, p uint8) ([
 	return tile, nil
 }
 
+// ReadEntryBundle looks up the entry bundle at the given index and width of
+// the bundle if partial, and returns the raw bytes of the bundle.
+func (s *storage) ReadEntryBundle(ctx context.Context, index uint64, p uint8) ([]byte, error) {
+	bundle, err := s.readEntryBundleFn(ctx, index, p)
+	if err != nil {
+		return nil, fmt.Errorf("reading entry bundle index %d p %d: %w", index, p, err)
+	}
+	return bundle, nil
+}
+
+// ReadCheckpoint returns the raw bytes of the latest published signed checkpoint.
+func (s *storage) ReadCheckpoint(ctx context.Context) ([]byte, error) {
+	checkpoint, err := s.readCheckpointFn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("reading checkpoint: %w", err)
+	}
+	return checkpoint, nil
+}
+
+// InclusionProof builds and verifies an inclusion proof for the entry at the given
+// index with the given leaf hash against the latest published checkpoint.
+func (s *storage) InclusionProof(ctx context.Context, index uint64, leafHash []byte) (*rekor_pb.InclusionProof, error) {
+	safeIdx, err := NewSafeInt64(index)
+	if err != nil {
+		return nil, fmt.Errorf("invalid index: %w", err)
+	}
+	checkpointBody, err := s.ReadCheckpoint(ctx)
+	if err != nil {
+		return nil, err
+	}
+	inclusionProof, err := s.buildProof(ctx, safeIdx, checkpointBody, leafHash)
+	if err != nil {
+		return nil, fmt.Errorf("building inclusion proof: %w", err)
+	}
+	return inclusionProof, nil
+}
+
 func (s *storage) addEntry(ctx context.Context, entry *tessera.Entry) (*SafeInt64, bool, []byte, error) {
 	idx, checkpointBody, err := s.awaiter.Await(ctx, s.addFn(ctx, entry))
 	if err != nil {
@@ -199,6 +418,9 @@ func (s *storage) buildProof(ctx context.Context, idx *SafeInt64, signedCheckpoi
 	if err != nil {
 		return nil, fmt.Errorf("unmarshalling checkpoint: %w", err)
 	}
+	if idx.U() >= checkpoint.Size {
+		return nil, NotIntegratedError{index: idx.U(), treeSize: checkpoint.Size}
+	}
 	proofBuilder, err := client.NewProofBuilder(ctx, checkpoint.Size, s.ReadTile)
 	if err != nil {
 		return nil, fmt.Errorf("new proof builder: %w", err)
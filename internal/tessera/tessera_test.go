@@ -19,6 +19,7 @@ import (
 	"context"
 	"encoding/hex"
 	"fmt"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -96,6 +97,33 @@ gb/AnEEsBNpTobDduU3OSNaiTp6liYf31FoE6AB/s8o=
 	}
 }
 
+func TestAddAsync(t *testing.T) {
+	ctx := context.Background()
+	entry := tessera.NewEntry([]byte("stuff"))
+	s := storage{}
+
+	s.addFn = func(_ context.Context, _ *tessera.Entry) tessera.IndexFuture {
+		return func() (tessera.Index, error) { return tessera.Index{Index: 5}, nil }
+	}
+	got, err := s.AddAsync(ctx, entry)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), got.LogIndex)
+	assert.Nil(t, got.InclusionProof)
+	assert.Equal(t, []byte("stuff"), got.CanonicalizedBody)
+
+	s.addFn = func(_ context.Context, _ *tessera.Entry) tessera.IndexFuture {
+		return func() (tessera.Index, error) { return tessera.Index{Index: 5, IsDup: true}, nil }
+	}
+	_, err = s.AddAsync(ctx, entry)
+	assert.ErrorAs(t, err, &DuplicateError{})
+
+	s.addFn = func(_ context.Context, _ *tessera.Entry) tessera.IndexFuture {
+		return func() (tessera.Index, error) { return tessera.Index{}, fmt.Errorf("server error") }
+	}
+	_, err = s.AddAsync(ctx, entry)
+	assert.ErrorContains(t, err, "add entry: server error")
+}
+
 func TestReadTile(t *testing.T) {
 	ctx := context.Background()
 	tileHash := hexDecodeOrDie(t, "81bfc09c412c04da53a1b0ddb94dce48d6a24e9ea58987f7d45a04e8007fb3ca")
@@ -143,6 +171,127 @@ func TestReadTile(t *testing.T) {
 	}
 }
 
+func TestReadEntryBundle(t *testing.T) {
+	ctx := context.Background()
+	s := storage{
+		readEntryBundleFn: func(_ context.Context, index uint64, p uint8) ([]byte, error) {
+			if index != 1 {
+				return nil, fmt.Errorf("not found")
+			}
+			return []byte(fmt.Sprintf("bundle %d %d", index, p)), nil
+		},
+	}
+	got, err := s.ReadEntryBundle(ctx, 1, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("bundle 1 5"), got)
+
+	_, err = s.ReadEntryBundle(ctx, 2, 0)
+	assert.ErrorContains(t, err, "reading entry bundle index 2 p 0: not found")
+}
+
+func TestReadCheckpoint(t *testing.T) {
+	ctx := context.Background()
+	checkpoint := []byte("test.origin\n1\ngb/AnEEsBNpTobDduU3OSNaiTp6liYf31FoE6AB/s8o=\n")
+	s := storage{
+		readCheckpointFn: func(_ context.Context) ([]byte, error) {
+			return checkpoint, nil
+		},
+	}
+	got, err := s.ReadCheckpoint(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, checkpoint, got)
+
+	s.readCheckpointFn = func(_ context.Context) ([]byte, error) {
+		return nil, fmt.Errorf("not found")
+	}
+	_, err = s.ReadCheckpoint(ctx)
+	assert.ErrorContains(t, err, "reading checkpoint: not found")
+}
+
+func TestInclusionProof(t *testing.T) {
+	ctx := context.Background()
+	tileHash := hexDecodeOrDie(t, "81bfc09c412c04da53a1b0ddb94dce48d6a24e9ea58987f7d45a04e8007fb3ca")
+	checkpoint := []byte(`test.origin
+1
+gb/AnEEsBNpTobDduU3OSNaiTp6liYf31FoE6AB/s8o=
+
+— test.origin AAAAAW5vb3AKMQpnYi9BbkVFc0JOcFRvYkRkdVUzT1NOYWlUcDZsaVlmMzFGb0U2QUIvczhvPQo=`)
+	s := storage{
+		readCheckpointFn: func(_ context.Context) ([]byte, error) {
+			return checkpoint, nil
+		},
+		readTileFn: func(_ context.Context, _, _ uint64, _ uint8) ([]byte, error) {
+			return tileHash, nil
+		},
+	}
+	leafHash := tessera.NewEntry([]byte("stuff")).LeafHash()
+
+	got, err := s.InclusionProof(ctx, 0, leafHash)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), got.LogIndex)
+	assert.Equal(t, int64(1), got.TreeSize)
+	assert.Equal(t, tileHash, got.RootHash)
+	assert.Equal(t, string(checkpoint), got.Checkpoint.Envelope)
+
+	_, err = s.InclusionProof(ctx, 1, leafHash)
+	assert.ErrorContains(t, err, "index 1 is not covered by checkpoint of size 1")
+
+	_, err = s.InclusionProof(ctx, 0, []byte("wrong leaf hash"))
+	assert.ErrorAs(t, err, &InclusionProofVerificationError{})
+}
+
+func TestReadEntryWithProof(t *testing.T) {
+	ctx := context.Background()
+	tileHash := hexDecodeOrDie(t, "81bfc09c412c04da53a1b0ddb94dce48d6a24e9ea58987f7d45a04e8007fb3ca")
+	checkpoint := []byte(`test.origin
+1
+gb/AnEEsBNpTobDduU3OSNaiTp6liYf31FoE6AB/s8o=
+
+— test.origin AAAAAW5vb3AKMQpnYi9BbkVFc0JOcFRvYkRkdVUzT1NOYWlUcDZsaVlmMzFGb0U2QUIvczhvPQo=`)
+	bundle := []byte("\x00\x05stuff")
+	s := storage{
+		readCheckpointFn: func(_ context.Context) ([]byte, error) {
+			return checkpoint, nil
+		},
+		readTileFn: func(_ context.Context, _, _ uint64, _ uint8) ([]byte, error) {
+			return tileHash, nil
+		},
+		readEntryBundleFn: func(_ context.Context, index uint64, p uint8) ([]byte, error) {
+			if index != 0 || p != 1 {
+				return nil, fmt.Errorf("unexpected entry bundle %d p %d", index, p)
+			}
+			return bundle, nil
+		},
+	}
+
+	got, err := s.ReadEntryWithProof(ctx, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), got.LogIndex)
+	assert.Equal(t, []byte("stuff"), got.CanonicalizedBody)
+	assert.Equal(t, int64(1), got.InclusionProof.TreeSize)
+	assert.Equal(t, tileHash, got.InclusionProof.RootHash)
+
+	_, err = s.ReadEntryWithProof(ctx, 1)
+	assert.ErrorAs(t, err, &NotIntegratedError{})
+
+	s.nextIndexFn = func(_ context.Context) (uint64, error) {
+		return 2, nil
+	}
+	_, err = s.ReadEntryWithProof(ctx, 1)
+	assert.ErrorAs(t, err, &NotIntegratedError{})
+	_, err = s.ReadEntryWithProof(ctx, 2)
+	assert.ErrorAs(t, err, &IndexOutOfRangeError{})
+	s.nextIndexFn = nil
+
+	bundle = []byte("\x00\x05other")
+	_, err = s.ReadEntryWithProof(ctx, 0)
+	assert.ErrorAs(t, err, &InclusionProofVerificationError{})
+
+	bundle = []byte("\x00\x05st")
+	_, err = s.ReadEntryWithProof(ctx, 0)
+	assert.ErrorContains(t, err, "parsing entry bundle 0")
+}
+
 func TestAppendOptions(t *testing.T) {
 	sv, _, err := signature.NewDefaultECDSASignerVerifier()
 	if err != nil {
@@ -158,6 +307,35 @@ func TestAppendOptions(t *testing.T) {
 	_ = WithAntispamOptions(ao, nil) // initializes non-persistent antispam
 }
 
+func TestNewDriver(t *testing.T) {
+	ctx := context.Background()
+	storageDir := filepath.Join(t.TempDir(), "log")
+
+	driver, as, err := NewDriver(ctx, DriverConfiguration{StorageDir: storageDir})
+	assert.NoError(t, err)
+	assert.NotNil(t, driver)
+	assert.Nil(t, as)
+
+	driver, as, err = NewDriver(ctx, DriverConfiguration{StorageDir: storageDir, PersistentAntispam: true})
+	assert.NoError(t, err)
+	assert.NotNil(t, driver)
+	assert.NotNil(t, as)
+	assert.DirExists(t, storageDir+"-antispam")
+
+	_, _, err = NewDriver(ctx, DriverConfiguration{AWSBucket: "bucket"})
+	assert.ErrorContains(t, err, "missing --aws-mysql-dsn")
+	_, _, err = NewDriver(ctx, DriverConfiguration{AWSMySQLDSN: "dsn"})
+	assert.ErrorContains(t, err, "missing --aws-bucket")
+
+	_, _, err = NewDriver(ctx, DriverConfiguration{GCPBucket: "bucket", GCPSpannerDB: "db", AWSBucket: "bucket", AWSMySQLDSN: "dsn"})
+	assert.ErrorContains(t, err, "conflicting Tessera drivers: GCP, AWS")
+	_, _, err = NewDriver(ctx, DriverConfiguration{AWSBucket: "bucket", StorageDir: storageDir})
+	assert.ErrorContains(t, err, "conflicting Tessera drivers: AWS, POSIX")
+
+	_, _, err = NewDriver(ctx, DriverConfiguration{})
+	assert.ErrorContains(t, err, "no flags provided to initialize Tessera driver")
+}
+
 func hexDecodeOrDie(t *testing.T, text string) []byte {
 	decoded, err := hex.DecodeString(text)
 	if err != nil {
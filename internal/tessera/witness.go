@@ -0,0 +1,49 @@
+// Copyright 2025 The Sigstore Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tessera
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/transparency-dev/tessera"
+)
+
+// DefaultWitnessTimeout is the default timeout for requests to witnesses.
+const DefaultWitnessTimeout = 10 * time.Second
+
+// WithWitnessOptions accepts an initialized AppendOptions and configures Tessera to send each new
+// checkpoint to the witnesses in the policy, and to publish the checkpoint only once enough witnesses
+// have cosigned it to satisfy the policy. The policy uses the Sigsum policy format, see
+// https://git.glasklar.is/sigsum/core/sigsum-go/-/blob/main/doc/policy.md, with witness URLs
+// implementing https://github.com/C2SP/C2SP/blob/main/tlog-witness.md.
+// If failOpen is true, checkpoints are published even if the policy can't be satisfied.
+// Returns the mutated options object for readability.
+func WithWitnessOptions(opts *tessera.AppendOptions, policy []byte, failOpen bool) (*tessera.AppendOptions, error) {
+	witnesses, err := tessera.NewWitnessGroupFromPolicy(policy)
+	if err != nil {
+		return nil, fmt.Errorf("parsing witness policy: %w", err)
+	}
+	return opts.WithWitnesses(witnesses, &tessera.WitnessOptions{FailOpen: failOpen}), nil
+}
+
+// witnessHTTPClient returns the client Tessera uses to request cosignatures from witnesses.
+func witnessHTTPClient(timeout time.Duration) *http.Client {
+	if timeout <= 0 {
+		timeout = DefaultWitnessTimeout
+	}
+	return &http.Client{Timeout: timeout}
+}
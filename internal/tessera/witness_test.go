@@ -0,0 +1,228 @@
+// Copyright 2025 The Sigstore Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tessera
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	rekornote "github.com/sigstore/rekor-tiles/v2/pkg/note"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/stretchr/testify/assert"
+	f_log "github.com/transparency-dev/formats/log"
+	f_note "github.com/transparency-dev/formats/note"
+	"github.com/transparency-dev/merkle/proof"
+	"github.com/transparency-dev/merkle/rfc6962"
+	"github.com/transparency-dev/tessera"
+	"golang.org/x/mod/sumdb/note"
+)
+
+// testWitness is a stand-in for a C2SP tlog-witness that cosigns checkpoints from a single log.
+type testWitness struct {
+	mu          sync.Mutex
+	logVerifier note.Verifier
+	cosigner    note.Signer
+	vkey        string
+	size        uint64
+	hash        []byte
+	// reject causes the witness to refuse to cosign any checkpoint
+	reject bool
+}
+
+func newTestWitness(t *testing.T, logVerifier note.Verifier) *testWitness {
+	t.Helper()
+	skey, vkey, err := note.GenerateKey(rand.Reader, "witness.example")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cosigner, err := f_note.NewSignerForCosignatureV1(skey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testWitness{logVerifier: logVerifier, cosigner: cosigner, vkey: vkey}
+}
+
+func (w *testWitness) setReject(reject bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.reject = reject
+}
+
+// ServeHTTP implements the add-checkpoint endpoint of https://github.com/C2SP/C2SP/blob/main/tlog-witness.md.
+func (w *testWitness) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	body, err := io.ReadAll(r.Body)
+	if err != nil || r.URL.Path != "/add-checkpoint" {
+		rw.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	header, cpRaw, ok := bytes.Cut(body, []byte("\n\n"))
+	if !ok {
+		rw.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	lines := strings.Split(string(header), "\n")
+	oldSize, err := strconv.ParseUint(strings.TrimPrefix(lines[0], "old "), 10, 64)
+	if err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	var consistency [][]byte
+	for _, l := range lines[1:] {
+		h, err := base64.StdEncoding.DecodeString(l)
+		if err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		consistency = append(consistency, h)
+	}
+	cp, _, n, err := f_log.ParseCheckpoint(cpRaw, w.logVerifier.Name(), w.logVerifier)
+	if err != nil || w.reject {
+		rw.WriteHeader(http.StatusForbidden)
+		return
+	}
+	if oldSize != w.size {
+		rw.Header().Set("Content-Type", "text/x.tlog.size")
+		rw.WriteHeader(http.StatusConflict)
+		fmt.Fprintf(rw, "%d\n", w.size)
+		return
+	}
+	if w.size > 0 {
+		if err := proof.VerifyConsistency(rfc6962.DefaultHasher, w.size, cp.Size, consistency, w.hash, cp.Hash); err != nil {
+			rw.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
+	}
+	cosigned, err := note.Sign(&note.Note{Text: n.Text}, w.cosigner)
+	if err != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.size, w.hash = cp.Size, cp.Hash
+	// Respond with only the cosignature lines
+	_, _ = rw.Write(cosigned[len(n.Text)+1:])
+}
+
+func TestWithWitnessOptions(t *testing.T) {
+	ao := tessera.NewAppendOptions()
+	_, err := WithWitnessOptions(ao, []byte("quorum none\n"), false)
+	assert.NoError(t, err)
+	_, err = WithWitnessOptions(ao, []byte("witness w1 not-a-key http://localhost\nquorum w1\n"), false)
+	assert.ErrorContains(t, err, "parsing witness policy")
+	_, err = WithWitnessOptions(ao, []byte("witness w1 not-a-key http://localhost\n"), false)
+	assert.ErrorContains(t, err, "parsing witness policy")
+}
+
+func TestWitnessedCheckpoints(t *testing.T) {
+	origin := "rekor.localhost"
+	sv, _, err := signature.NewDefaultECDSASignerVerifier()
+	if err != nil {
+		t.Fatal(err)
+	}
+	logVerifier, err := rekornote.NewNoteVerifier(origin, sv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// newStorage starts a new log witnessed by a new witness
+	newStorage := func(t *testing.T, failOpen bool) (Storage, *testWitness, note.Verifier) {
+		witness := newTestWitness(t, logVerifier)
+		witnessServer := httptest.NewServer(witness)
+		t.Cleanup(witnessServer.Close)
+		cosigVerifier, err := f_note.NewVerifierForCosignatureV1(witness.vkey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		driver, _, err := NewDriver(ctx, DriverConfiguration{StorageDir: filepath.Join(t.TempDir(), "log"), WitnessTimeout: 1 * time.Second})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ao, err := NewAppendOptions(ctx, origin, sv)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ao = WithLifecycleOptions(ao, 10, 10*time.Millisecond, 100*time.Millisecond, DefaultPushbackMaxOutstanding)
+		policy := fmt.Sprintf("witness w1 %s %s\nquorum w1\n", witness.vkey, witnessServer.URL)
+		ao, err = WithWitnessOptions(ao, []byte(policy), failOpen)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s, shutdown, err := NewStorage(ctx, origin, driver, ao)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() {
+			_ = shutdown(context.Background())
+			cancel()
+		})
+		return s, witness, cosigVerifier
+	}
+
+	t.Run("cosigned", func(t *testing.T) {
+		s, _, cosigVerifier := newStorage(t, false)
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		for i := range 2 {
+			tle, err := s.Add(ctx, tessera.NewEntry(fmt.Appendf(nil, "entry %d", i)))
+			if !assert.NoError(t, err) {
+				return
+			}
+			cp := []byte(tle.GetInclusionProof().GetCheckpoint().GetEnvelope())
+			n, err := note.Open(cp, note.VerifierList(logVerifier, cosigVerifier))
+			if assert.NoError(t, err) {
+				assert.Len(t, n.Sigs, 2, "checkpoint should be signed by the log and the witness")
+			}
+		}
+	})
+
+	// The witness starts rejecting checkpoints after the log's initial checkpoint has been published
+	t.Run("policy not satisfied", func(t *testing.T) {
+		s, witness, _ := newStorage(t, false)
+		witness.setReject(true)
+		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		defer cancel()
+		_, err := s.Add(ctx, tessera.NewEntry([]byte("unwitnessed")))
+		assert.Error(t, err)
+	})
+
+	t.Run("policy not satisfied with fail open", func(t *testing.T) {
+		s, witness, cosigVerifier := newStorage(t, true)
+		witness.setReject(true)
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		tle, err := s.Add(ctx, tessera.NewEntry([]byte("unwitnessed")))
+		if assert.NoError(t, err) {
+			cp := []byte(tle.GetInclusionProof().GetCheckpoint().GetEnvelope())
+			n, err := note.Open(cp, note.VerifierList(logVerifier, cosigVerifier))
+			if assert.NoError(t, err) {
+				assert.Len(t, n.Sigs, 1, "checkpoint should only be signed by the log")
+			}
+		}
+	})
+}
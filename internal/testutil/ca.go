@@ -0,0 +1,103 @@
+//
+// Copyright 2025 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package testutil provides fixtures shared by tests.
+package testutil
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+)
+
+// CA is a test certificate authority with an ECDSA P-256 key.
+type CA struct {
+	Cert *x509.Certificate
+	Key  *ecdsa.PrivateKey
+}
+
+// NewCA returns a CA valid for a day either side of now, issued by parent, or
+// self-signed if parent is nil.
+func NewCA(t testing.TB, name string, parent *CA) *CA {
+	t.Helper()
+	key := newKey(t)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-24 * time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	issuer, issuerKey := template, key
+	if parent != nil {
+		issuer, issuerKey = parent.Cert, parent.Key
+	}
+	return &CA{Cert: createCert(t, template, issuer, key.Public(), issuerKey), Key: key}
+}
+
+// IssueLeaf returns a leaf certificate issued by the CA with the given validity
+// period and extended key usage, and its private key.
+func (ca *CA) IssueLeaf(t testing.TB, notBefore, notAfter time.Time, extKeyUsage x509.ExtKeyUsage) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+	key := newKey(t)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "leaf"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{extKeyUsage},
+	}
+	return createCert(t, template, ca.Cert, key.Public(), ca.Key), key
+}
+
+// NewCertChain returns a root, an intermediate issued by the root, and a code signing
+// leaf certificate valid for the next few minutes issued by the intermediate, with
+// the leaf's private key.
+func NewCertChain(t testing.TB) (*x509.Certificate, *x509.Certificate, *x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+	root := NewCA(t, "root", nil)
+	intermediate := NewCA(t, "intermediate", root)
+	leaf, leafKey := intermediate.IssueLeaf(t, time.Now().Add(-time.Minute), time.Now().Add(10*time.Minute), x509.ExtKeyUsageCodeSigning)
+	return root.Cert, intermediate.Cert, leaf, leafKey
+}
+
+func newKey(t testing.TB) *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func createCert(t testing.TB, template, parent *x509.Certificate, pub crypto.PublicKey, priv crypto.Signer) *x509.Certificate {
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, pub, priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
@@ -0,0 +1,238 @@
+//
+// Copyright 2025 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// TileCache caches full tiles and entry bundles, which never change once written.
+// Read clients never cache partial tiles or entry bundles, and delete cached data
+// read by an operation that fails, since a mirror may have served bad data. Caching
+// is best effort, so implementations do not return errors.
+type TileCache interface {
+	// Get returns the cached data for key, and whether it was found.
+	Get(ctx context.Context, key string) ([]byte, bool)
+	// Put caches data for key.
+	Put(ctx context.Context, key string, data []byte)
+	// Delete removes the cached data for key, if any.
+	Delete(ctx context.Context, key string)
+}
+
+// MemoryTileCache is an in-memory TileCache that evicts the least recently used
+// entries once it holds its maximum number of entries.
+type MemoryTileCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	entries    map[string]*list.Element
+	lru        *list.List
+}
+
+type memoryCacheEntry struct {
+	key  string
+	data []byte
+}
+
+// NewMemoryTileCache returns an in-memory TileCache holding up to maxEntries tiles
+// and entry bundles. A full tile is 8KiB, while the size of an entry bundle depends
+// on the size of its entries.
+func NewMemoryTileCache(maxEntries int) *MemoryTileCache {
+	return &MemoryTileCache{
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+	}
+}
+
+// Get returns the cached data for key, marking it as recently used.
+func (m *MemoryTileCache) Get(_ context.Context, key string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	m.lru.MoveToFront(e)
+	return e.Value.(*memoryCacheEntry).data, true
+}
+
+// Put caches data for key, evicting the least recently used entry if the cache is full.
+func (m *MemoryTileCache) Put(_ context.Context, key string, data []byte) {
+	if m.maxEntries <= 0 {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if e, ok := m.entries[key]; ok {
+		m.lru.MoveToFront(e)
+		return
+	}
+	m.entries[key] = m.lru.PushFront(&memoryCacheEntry{key: key, data: data})
+	if m.lru.Len() > m.maxEntries {
+		oldest := m.lru.Back()
+		m.lru.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memoryCacheEntry).key)
+	}
+}
+
+// Delete removes the cached data for key.
+func (m *MemoryTileCache) Delete(_ context.Context, key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if e, ok := m.entries[key]; ok {
+		m.lru.Remove(e)
+		delete(m.entries, key)
+	}
+}
+
+// DiskTileCache is a TileCache that stores tiles and entry bundles in a directory
+// by content address: each is written once to objects/, in a file named by the
+// SHA-256 hash of its data, and refs/ maps the hash of each key to the hash of its
+// data. Data read back is checked against its hash, so a corrupted file is never
+// returned. Deleting a key removes only its reference, leaving data that other keys
+// may share. The directory can be shared by clients of different logs, since keys
+// include the log's origin.
+type DiskTileCache struct {
+	dir string
+}
+
+// NewDiskTileCache returns a TileCache that stores tiles and entry bundles in dir,
+// which is created if needed.
+func NewDiskTileCache(dir string) *DiskTileCache {
+	return &DiskTileCache{dir: dir}
+}
+
+// hashPath returns the path of the file named by the hex-encoded hash in the
+// subdirectory kind, sharded by the hash's first byte.
+func (d *DiskTileCache) hashPath(kind, hash string) string {
+	return filepath.Join(d.dir, kind, hash[:2], hash)
+}
+
+func (d *DiskTileCache) refPath(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return d.hashPath("refs", hex.EncodeToString(sum[:]))
+}
+
+// Get returns the data stored for key, or false if it is not stored, cannot be read,
+// or does not match its content hash, in which case the reference and the corrupt
+// data are removed.
+func (d *DiskTileCache) Get(ctx context.Context, key string) ([]byte, bool) {
+	ref, err := os.ReadFile(d.refPath(key))
+	if err != nil {
+		return nil, false
+	}
+	hash := string(ref)
+	if !isHexHash(hash) {
+		d.Delete(ctx, key)
+		return nil, false
+	}
+	data, err := os.ReadFile(d.hashPath("objects", hash))
+	if err != nil {
+		return nil, false
+	}
+	if sum := sha256.Sum256(data); hex.EncodeToString(sum[:]) != hash {
+		// The object is corrupt for every key referring to it
+		_ = os.Remove(d.hashPath("objects", hash))
+		d.Delete(ctx, key)
+		return nil, false
+	}
+	return data, true
+}
+
+// Put stores data under its content hash, if not already stored, and then points
+// key at it. Errors are ignored.
+func (d *DiskTileCache) Put(_ context.Context, key string, data []byte) {
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+	object := d.hashPath("objects", hash)
+	if _, err := os.Stat(object); err != nil && !writeFileAtomic(object, data) {
+		return
+	}
+	_ = writeFileAtomic(d.refPath(key), []byte(hash))
+}
+
+// Delete removes the reference stored for key. The data it points to is kept, since
+// other keys may refer to it. Errors are ignored.
+func (d *DiskTileCache) Delete(_ context.Context, key string) {
+	_ = os.Remove(d.refPath(key))
+}
+
+// isHexHash returns true if hash is a hex-encoded SHA-256 hash.
+func isHexHash(hash string) bool {
+	_, err := hex.DecodeString(hash)
+	return err == nil && len(hash) == 2*sha256.Size
+}
+
+// writeFileAtomic writes data to a temporary file that is renamed to path, so that
+// concurrent readers never see a partially written file. It returns false on error.
+func writeFileAtomic(path string, data []byte) bool {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return false
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return false
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return false
+	}
+	if err := tmp.Close(); err != nil {
+		return false
+	}
+	return os.Rename(tmp.Name(), path) == nil
+}
+
+// tieredTileCache checks each cache in order.
+type tieredTileCache []TileCache
+
+// NewTieredTileCache returns a TileCache that checks each cache in order, such as
+// a MemoryTileCache in front of a DiskTileCache. Data found in a later cache is
+// added to the earlier caches, and new data is added to all caches.
+func NewTieredTileCache(caches ...TileCache) TileCache {
+	return tieredTileCache(caches)
+}
+
+func (t tieredTileCache) Get(ctx context.Context, key string) ([]byte, bool) {
+	for i, c := range t {
+		if data, ok := c.Get(ctx, key); ok {
+			for _, earlier := range t[:i] {
+				earlier.Put(ctx, key, data)
+			}
+			return data, true
+		}
+	}
+	return nil, false
+}
+
+func (t tieredTileCache) Put(ctx context.Context, key string, data []byte) {
+	for _, c := range t {
+		c.Put(ctx, key, data)
+	}
+}
+
+func (t tieredTileCache) Delete(ctx context.Context, key string) {
+	for _, c := range t {
+		c.Delete(ctx, key)
+	}
+}
@@ -0,0 +1,138 @@
+//
+// Copyright 2025 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryTileCache(t *testing.T) {
+	ctx := context.Background()
+	cache := NewMemoryTileCache(2)
+	cache.Put(ctx, "a", []byte("a"))
+	cache.Put(ctx, "b", []byte("b"))
+	// a is now more recently used than b
+	data, ok := cache.Get(ctx, "a")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("a"), data)
+	cache.Put(ctx, "c", []byte("c"))
+	_, ok = cache.Get(ctx, "b")
+	assert.False(t, ok, "least recently used entry should be evicted")
+	for _, key := range []string{"a", "c"} {
+		data, ok := cache.Get(ctx, key)
+		assert.True(t, ok)
+		assert.Equal(t, []byte(key), data)
+	}
+
+	cache.Delete(ctx, "a")
+	_, ok = cache.Get(ctx, "a")
+	assert.False(t, ok)
+	cache.Delete(ctx, "missing")
+
+	disabled := NewMemoryTileCache(0)
+	disabled.Put(ctx, "a", []byte("a"))
+	_, ok = disabled.Get(ctx, "a")
+	assert.False(t, ok)
+}
+
+func TestDiskTileCache(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	cache := NewDiskTileCache(dir)
+	_, ok := cache.Get(ctx, "origin/tile/0/000")
+	assert.False(t, ok)
+	cache.Put(ctx, "origin/tile/0/000", []byte("tile"))
+	cache.Put(ctx, "other/tile/0/000", []byte("other tile"))
+
+	// Entries persist across instances
+	cache = NewDiskTileCache(dir)
+	data, ok := cache.Get(ctx, "origin/tile/0/000")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("tile"), data)
+	data, ok = cache.Get(ctx, "other/tile/0/000")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("other tile"), data)
+
+	cache.Delete(ctx, "origin/tile/0/000")
+	_, ok = cache.Get(ctx, "origin/tile/0/000")
+	assert.False(t, ok)
+	_, ok = cache.Get(ctx, "other/tile/0/000")
+	assert.True(t, ok)
+
+	// Identical data is stored once
+	cache.Put(ctx, "a/tile/0/000", []byte("same"))
+	cache.Put(ctx, "b/tile/0/000", []byte("same"))
+	sum := sha256.Sum256([]byte("same"))
+	object := filepath.Join(dir, "objects", hex.EncodeToString(sum[:1]), hex.EncodeToString(sum[:]))
+	_, err := os.Stat(object)
+	assert.NoError(t, err)
+
+	// Deleting one key keeps the data shared with another
+	cache.Delete(ctx, "b/tile/0/000")
+	_, ok = cache.Get(ctx, "b/tile/0/000")
+	assert.False(t, ok)
+	data, ok = cache.Get(ctx, "a/tile/0/000")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("same"), data)
+	cache.Put(ctx, "b/tile/0/000", []byte("same"))
+
+	// Corrupted data is not returned
+	if err := os.WriteFile(object, []byte("corrupted"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	_, ok = cache.Get(ctx, "a/tile/0/000")
+	assert.False(t, ok)
+	_, ok = cache.Get(ctx, "b/tile/0/000")
+	assert.False(t, ok)
+	_, err = os.Stat(object)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestTieredTileCache(t *testing.T) {
+	ctx := context.Background()
+	memory := NewMemoryTileCache(10)
+	disk := NewDiskTileCache(t.TempDir())
+	cache := NewTieredTileCache(memory, disk)
+
+	cache.Put(ctx, "a", []byte("a"))
+	for _, c := range []TileCache{memory, disk} {
+		_, ok := c.Get(ctx, "a")
+		assert.True(t, ok)
+	}
+
+	disk.Put(ctx, "b", []byte("b"))
+	data, ok := cache.Get(ctx, "b")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("b"), data)
+	_, ok = memory.Get(ctx, "b")
+	assert.True(t, ok, "entry found on disk should be added to memory")
+
+	_, ok = cache.Get(ctx, "c")
+	assert.False(t, ok)
+
+	cache.Delete(ctx, "a")
+	for _, c := range []TileCache{memory, disk} {
+		_, ok := c.Get(ctx, "a")
+		assert.False(t, ok)
+	}
+}
@@ -0,0 +1,76 @@
+//
+// Copyright 2025 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// CheckpointStore persists the last trusted checkpoint of a log between runs.
+type CheckpointStore interface {
+	// Load returns the stored signed checkpoint, or nil if no checkpoint has been stored.
+	Load(ctx context.Context) ([]byte, error)
+	// Store replaces the stored checkpoint with the signed checkpoint.
+	Store(ctx context.Context, checkpoint []byte) error
+}
+
+// FileCheckpointStore stores a signed checkpoint in a file.
+type FileCheckpointStore struct {
+	path string
+}
+
+// NewFileCheckpointStore returns a CheckpointStore that stores the checkpoint at path.
+// The file is created on the first Store, and is replaced atomically on each update.
+func NewFileCheckpointStore(path string) *FileCheckpointStore {
+	return &FileCheckpointStore{path: path}
+}
+
+// Load returns the checkpoint stored in the file, or nil if the file does not exist.
+func (f *FileCheckpointStore) Load(_ context.Context) ([]byte, error) {
+	checkpoint, err := os.ReadFile(f.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading checkpoint: %w", err)
+	}
+	return checkpoint, nil
+}
+
+// Store writes the checkpoint to a temporary file and renames it over the stored file.
+func (f *FileCheckpointStore) Store(_ context.Context, checkpoint []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("creating checkpoint file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(checkpoint); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing checkpoint: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing checkpoint: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), f.path); err != nil {
+		return fmt.Errorf("replacing checkpoint: %w", err)
+	}
+	return nil
+}
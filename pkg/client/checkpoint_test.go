@@ -0,0 +1,49 @@
+//
+// Copyright 2025 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileCheckpointStore(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store := NewFileCheckpointStore(filepath.Join(dir, "checkpoint"))
+
+	checkpoint, err := store.Load(ctx)
+	assert.NoError(t, err)
+	assert.Nil(t, checkpoint)
+
+	for _, want := range []string{"first checkpoint\n", "second checkpoint\n"} {
+		assert.NoError(t, store.Store(ctx, []byte(want)))
+		checkpoint, err = store.Load(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, want, string(checkpoint))
+	}
+	// Temporary files are removed
+	files, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+
+	store = NewFileCheckpointStore(filepath.Join(dir, "missing", "checkpoint"))
+	assert.Error(t, store.Store(ctx, []byte("checkpoint\n")))
+}
@@ -0,0 +1,58 @@
+//
+// Copyright 2025 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"crypto/tls"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+)
+
+// NewGRPCConn creates a gRPC client connection to the target, a host and port, using
+// the transport security, user agent, keepalive and retry options from the Config.
+func NewGRPCConn(target string, cfg *Config) (*grpc.ClientConn, error) {
+	var creds credentials.TransportCredentials
+	switch {
+	case cfg.Insecure:
+		creds = insecure.NewCredentials()
+	case cfg.TLSConfig != nil:
+		creds = credentials.NewTLS(cfg.TLSConfig)
+	default:
+		creds = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	}
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	if cfg.UserAgent != "" {
+		opts = append(opts, grpc.WithUserAgent(cfg.UserAgent))
+	}
+	if cfg.KeepaliveTime > 0 {
+		opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:    cfg.KeepaliveTime,
+			Timeout: cfg.KeepaliveTimeout,
+		}))
+	}
+	if cfg.Retry != nil {
+		opts = append(opts, grpc.WithUnaryInterceptor(retryInterceptor(*cfg.Retry)))
+	}
+	conn, err := grpc.NewClient(target, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating gRPC client for %s: %w", target, err)
+	}
+	return conn, nil
+}
@@ -15,12 +15,56 @@
 
 package client
 
-import "time"
+import (
+	"crypto/tls"
+	"fmt"
+	"time"
+
+	rekornote "github.com/sigstore/rekor-tiles/v2/pkg/note"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"golang.org/x/mod/sumdb/note"
+)
 
 // Config contains connection options for the client.
 type Config struct {
-	UserAgent string
-	Timeout   time.Duration
+	UserAgent      string
+	Timeout        time.Duration
+	ReturnExisting bool
+	TLSConfig      *tls.Config
+	// Insecure disables transport security for gRPC clients.
+	Insecure         bool
+	KeepaliveTime    time.Duration
+	KeepaliveTimeout time.Duration
+	// Verifier verifies the checkpoints of entries returned by write clients.
+	Verifier note.Verifier
+	// Origin and LogVerifier are used to construct Verifier if it is not set.
+	Origin      string
+	LogVerifier signature.Verifier
+	// Retry configures retries of failed requests. If nil, requests are not retried.
+	Retry *RetryPolicy
+	// Mirrors are base URLs that read clients fall back to, in order, after the read URL.
+	Mirrors []string
+	// MirrorTimeout bounds each request to a single mirror before falling back to the next.
+	MirrorTimeout time.Duration
+	// CheckpointStore persists the last trusted checkpoint for read clients.
+	CheckpointStore CheckpointStore
+	// TileCache caches full tiles and entry bundles for read clients.
+	TileCache TileCache
+	// MaxConcurrentFetches bounds the number of entry bundles read clients fetch concurrently.
+	MaxConcurrentFetches int
+}
+
+// NoteVerifier returns the checkpoint verifier configured with WithVerifier or
+// WithLogVerifier, or nil if neither was set.
+func (c *Config) NoteVerifier() (note.Verifier, error) {
+	if c.Verifier != nil || c.LogVerifier == nil {
+		return c.Verifier, nil
+	}
+	v, err := rekornote.NewNoteVerifier(c.Origin, c.LogVerifier)
+	if err != nil {
+		return nil, fmt.Errorf("creating note verifier: %w", err)
+	}
+	return v, nil
 }
 
 // Option customizes the client Config.
@@ -39,3 +83,104 @@ func WithTimeout(timeout time.Duration) Option {
 		c.Timeout = timeout
 	}
 }
+
+// WithReturnExisting requests that the write client receive the existing entry
+// with a fresh inclusion proof, rather than an error, when submitting an entry
+// that is already in the log.
+func WithReturnExisting() Option {
+	return func(c *Config) {
+		c.ReturnExisting = true
+	}
+}
+
+// WithTLSConfig sets the TLS configuration used to connect to the server, for example
+// to trust a private CA or to present a client certificate for mutual TLS.
+// See LoadTLSConfig to construct a configuration from PEM files.
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return func(c *Config) {
+		c.TLSConfig = tlsConfig
+	}
+}
+
+// WithInsecure disables transport security for gRPC clients, which otherwise
+// connect using TLS. HTTP clients use the scheme of the URL instead.
+func WithInsecure() Option {
+	return func(c *Config) {
+		c.Insecure = true
+	}
+}
+
+// WithKeepalive configures gRPC clients to ping the server after the connection
+// has been idle for the given interval, closing the connection if no response is
+// received within the timeout. The interval must not be shorter than the minimum
+// allowed by the server, which defaults to 5 minutes.
+func WithKeepalive(interval, timeout time.Duration) Option {
+	return func(c *Config) {
+		c.KeepaliveTime = interval
+		c.KeepaliveTimeout = timeout
+	}
+}
+
+// WithVerifier configures write clients to verify each returned entry's checkpoint
+// signature and inclusion proof, and that the entry's canonicalized body matches
+// the submitted request, before returning it.
+func WithVerifier(verifier note.Verifier) Option {
+	return func(c *Config) {
+		c.Verifier = verifier
+	}
+}
+
+// WithLogVerifier is like WithVerifier, using the log's origin and public key
+// to verify checkpoints.
+func WithLogVerifier(origin string, verifier signature.Verifier) Option {
+	return func(c *Config) {
+		c.Origin = origin
+		c.LogVerifier = verifier
+	}
+}
+
+// WithMaxConcurrentFetches sets the number of entry bundles read clients fetch
+// concurrently when iterating over entries.
+func WithMaxConcurrentFetches(n int) Option {
+	return func(c *Config) {
+		c.MaxConcurrentFetches = n
+	}
+}
+
+// WithCheckpointStore configures read clients to trust the first checkpoint they read
+// and persist it in store. Each later checkpoint must be consistent with the stored
+// checkpoint, which it then replaces.
+func WithCheckpointStore(store CheckpointStore) Option {
+	return func(c *Config) {
+		c.CheckpointStore = store
+	}
+}
+
+// WithMirrors configures HTTP read clients with mirrors of the log, such as CDNs,
+// that serve the same checkpoints, tiles and entry bundles as the read URL. Each
+// request is sent to the fastest responsive mirror, falling back to the others in
+// order on failure. Mirrors need not be trusted, since checkpoints are verified with
+// the log's key and proofs built from tiles are verified against the checkpoint.
+func WithMirrors(urls ...string) Option {
+	return func(c *Config) {
+		c.Mirrors = append(c.Mirrors, urls...)
+	}
+}
+
+// WithMirrorTimeout sets how long HTTP read clients wait for a single mirror before
+// falling back to the next one, so that a mirror that hangs does not use up the
+// caller's deadline. Defaults to 10 seconds. A non-positive timeout disables it.
+func WithMirrorTimeout(timeout time.Duration) Option {
+	return func(c *Config) {
+		c.MirrorTimeout = timeout
+	}
+}
+
+// WithTileCache configures read clients to cache full tiles and entry bundles, which
+// never change once written. Partial tiles and entry bundles are never cached.
+// See NewMemoryTileCache, NewDiskTileCache and NewTieredTileCache.
+func WithTileCache(cache TileCache) Option {
+	return func(c *Config) {
+		c.TileCache = cache
+	}
+}
@@ -0,0 +1,110 @@
+//
+// Copyright 2025 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package read
+
+import (
+	"context"
+	"iter"
+	"sync"
+
+	"github.com/sigstore/rekor-tiles/v2/pkg/client"
+	"github.com/transparency-dev/tessera/api/layout"
+)
+
+// cachedRead returns the data at path in the log with the origin from the cache,
+// or reads and caches it. Only full tiles and entry bundles, with a width p of 0,
+// are cached, since partial ones are replaced as the log grows.
+func cachedRead(ctx context.Context, cache client.TileCache, origin, path string, p uint8, read func() ([]byte, error)) ([]byte, error) {
+	if cache == nil || p != 0 {
+		return read()
+	}
+	key := cacheKey(origin, path)
+	if data, ok := cache.Get(ctx, key); ok {
+		return data, nil
+	}
+	data, err := read()
+	if err != nil {
+		return nil, err
+	}
+	cache.Put(ctx, key, data)
+	return data, nil
+}
+
+func cacheKey(origin, path string) string {
+	return origin + "/" + path
+}
+
+// evictingReader records the full tiles and entry bundles read through it, so that
+// they can be deleted from the cache if the operation reading them fails. Data is
+// cached as soon as it is read, before it can be verified against a checkpoint, so
+// without eviction a bad response from a mirror would be served from the cache forever.
+type evictingReader struct {
+	entryReader
+	cache  client.TileCache
+	origin string
+
+	mu   sync.Mutex
+	keys []string
+}
+
+func newEvictingReader(r entryReader, cache client.TileCache, origin string) *evictingReader {
+	return &evictingReader{entryReader: r, cache: cache, origin: origin}
+}
+
+func (e *evictingReader) ReadTile(ctx context.Context, level, index uint64, p uint8) ([]byte, error) {
+	e.record(layout.TilePath(level, index, p), p)
+	return e.entryReader.ReadTile(ctx, level, index, p)
+}
+
+func (e *evictingReader) ReadEntryBundle(ctx context.Context, index uint64, p uint8) ([]byte, error) {
+	e.record(layout.EntriesPath(index, p), p)
+	return e.entryReader.ReadEntryBundle(ctx, index, p)
+}
+
+func (e *evictingReader) record(path string, p uint8) {
+	if e.cache == nil || p != 0 {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.keys = append(e.keys, cacheKey(e.origin, path))
+}
+
+// evict deletes the recorded tiles and entry bundles from the cache if err is not nil
+// and was not caused by the context, and returns err.
+func (e *evictingReader) evict(ctx context.Context, err error) error {
+	if err == nil || e.cache == nil || ctx.Err() != nil {
+		return err
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, key := range e.keys {
+		e.cache.Delete(ctx, key)
+	}
+	e.keys = nil
+	return err
+}
+
+// evictSeq is like evict, for the first error yielded by seq.
+func (e *evictingReader) evictSeq(ctx context.Context, seq iter.Seq2[*LogEntry, error]) iter.Seq2[*LogEntry, error] {
+	return func(yield func(*LogEntry, error) bool) {
+		for entry, err := range seq {
+			if !yield(entry, e.evict(ctx, err)) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,102 @@
+//
+// Copyright 2025 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package read
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/sigstore/rekor-tiles/v2/pkg/client"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTileCache(t *testing.T) {
+	origin := "rekor-local"
+	sv, _, err := signature.NewDefaultECDSASignerVerifier()
+	if err != nil {
+		t.Fatal(err)
+	}
+	log := newTestLog(t, origin, sv)
+	// A full tile and entry bundle, and partial ones
+	log.add(t, 300)
+	var mu sync.Mutex
+	var paths []string
+	server, _ := countingServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+		proxy(t, log.server.URL).ServeHTTP(w, r)
+	}))
+	requested := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		requested := slices.Clone(paths)
+		paths = nil
+		slices.Sort(requested)
+		return slices.Compact(requested)
+	}
+	cache := client.NewMemoryTileCache(100)
+	reader, err := NewReader(server.URL, origin, sv, client.WithTileCache(cache))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	walk := func(t *testing.T) {
+		for _, err := range reader.Entries(ctx, 0, 300) {
+			if !assert.NoError(t, err) {
+				return
+			}
+		}
+	}
+	walk(t)
+	first := requested()
+	assert.Contains(t, first, "/tile/0/000")
+	assert.Contains(t, first, "/tile/entries/000")
+
+	walk(t)
+	for _, path := range requested() {
+		assert.True(t, path == "/checkpoint" || strings.Contains(path, ".p/"), "%s should be cached", path)
+	}
+
+	// Keys include the origin, so caches can be shared between logs
+	_, ok := cache.Get(ctx, origin+"/tile/0/000")
+	assert.True(t, ok)
+	_, ok = cache.Get(ctx, "other/tile/0/000")
+	assert.False(t, ok)
+
+	// Bad data served by a mirror is evicted once it fails verification
+	for _, key := range []string{origin + "/tile/0/000", origin + "/tile/entries/000"} {
+		cache.Delete(ctx, key)
+		cache.Put(ctx, key, bytes.Repeat([]byte{1}, 8192))
+		var errs []error
+		for _, err := range reader.Entries(ctx, 0, 300) {
+			errs = append(errs, err)
+		}
+		assert.Len(t, errs, 1, key)
+		_, ok = cache.Get(ctx, key)
+		assert.False(t, ok, "%s should be evicted", key)
+		walk(t)
+		_, ok = cache.Get(ctx, key)
+		assert.True(t, ok, "%s should be cached again", key)
+	}
+}
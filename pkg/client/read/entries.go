@@ -0,0 +1,173 @@
+//
+// Copyright 2025 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package read
+
+import (
+	"context"
+	"fmt"
+	"iter"
+
+	pb "github.com/sigstore/rekor-tiles/v2/pkg/generated/protobuf"
+	"github.com/transparency-dev/formats/log"
+	"github.com/transparency-dev/merkle/proof"
+	"github.com/transparency-dev/merkle/rfc6962"
+	"github.com/transparency-dev/tessera/api/layout"
+	tclient "github.com/transparency-dev/tessera/client"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// DefaultMaxConcurrentFetches is the number of entry bundles fetched concurrently
+// when iterating over entries, unless configured with client.WithMaxConcurrentFetches.
+const DefaultMaxConcurrentFetches = 8
+
+// LogEntry is an entry read from the log.
+type LogEntry struct {
+	// Index is the entry's index in the log
+	Index uint64
+	// Entry is the decoded entry
+	Entry *pb.Entry
+	// CanonicalizedBody is the entry as stored in the log, from which its leaf hash is computed
+	CanonicalizedBody []byte
+}
+
+// entryReader reads verified checkpoints, tiles and entry bundles, from which
+// entries are read and verified.
+type entryReader interface {
+	tileReader
+	ReadEntryBundle(context.Context, uint64, uint8) ([]byte, error)
+}
+
+// getEntry returns the entry at index, verified by an inclusion proof against the
+// latest checkpoint.
+func getEntry(ctx context.Context, r entryReader, index uint64) (*LogEntry, error) {
+	cp, _, err := r.ReadCheckpoint(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if index >= cp.Size {
+		return nil, fmt.Errorf("index %d out of range for checkpoint size %d", index, cp.Size)
+	}
+	entries, err := readBundle(ctx, r, cp, index/layout.EntryBundleWidth)
+	if err != nil {
+		return nil, err
+	}
+	return entries[index%layout.EntryBundleWidth], nil
+}
+
+// entries returns an iterator over the entries in [start, end), verified by inclusion
+// proofs against the latest checkpoint. Up to maxFetches entry bundles are fetched
+// ahead of the caller. Iteration stops after the first error.
+func entries(ctx context.Context, r entryReader, start, end uint64, maxFetches int) iter.Seq2[*LogEntry, error] {
+	return func(yield func(*LogEntry, error) bool) {
+		cp, _, err := r.ReadCheckpoint(ctx)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		if start > end || end > cp.Size {
+			yield(nil, fmt.Errorf("range [%d, %d) out of range for checkpoint size %d", start, end, cp.Size))
+			return
+		}
+		if start == end {
+			return
+		}
+		if maxFetches <= 0 {
+			maxFetches = DefaultMaxConcurrentFetches
+		}
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		type result struct {
+			entries []*LogEntry
+			err     error
+		}
+		// Bundles are fetched in order, with results buffered until the caller reaches them
+		results := make(chan chan result, maxFetches-1)
+		go func() {
+			defer close(results)
+			for i := start / layout.EntryBundleWidth; i <= (end-1)/layout.EntryBundleWidth; i++ {
+				res := make(chan result, 1)
+				select {
+				case results <- res:
+				case <-ctx.Done():
+					return
+				}
+				go func() {
+					entries, err := readBundle(ctx, r, cp, i)
+					res <- result{entries: entries, err: err}
+				}()
+			}
+		}()
+		for res := range results {
+			var bundle result
+			select {
+			case bundle = <-res:
+			case <-ctx.Done():
+				yield(nil, ctx.Err())
+				return
+			}
+			if bundle.err != nil {
+				yield(nil, bundle.err)
+				return
+			}
+			for _, e := range bundle.entries {
+				if e.Index < start || e.Index >= end {
+					continue
+				}
+				if !yield(e, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// readBundle returns the decoded entries of the entry bundle at index, as published
+// for the checkpoint, verifying each against the checkpoint's root hash.
+func readBundle(ctx context.Context, r entryReader, cp *log.Checkpoint, index uint64) ([]*LogEntry, error) {
+	bundle, err := tclient.GetEntryBundle(ctx, r.ReadEntryBundle, index, cp.Size)
+	if err != nil {
+		return nil, fmt.Errorf("fetching entry bundle %d: %w", index, err)
+	}
+	want := uint64(layout.PartialTileSize(0, index, cp.Size))
+	if want == 0 {
+		want = layout.EntryBundleWidth
+	}
+	if uint64(len(bundle.Entries)) != want {
+		return nil, fmt.Errorf("entry bundle %d has %d entries, expected %d", index, len(bundle.Entries), want)
+	}
+	proofBuilder, err := tclient.NewProofBuilder(ctx, cp.Size, r.ReadTile)
+	if err != nil {
+		return nil, fmt.Errorf("creating proof builder: %w", err)
+	}
+	entries := make([]*LogEntry, len(bundle.Entries))
+	for i, body := range bundle.Entries {
+		leafIndex := index*layout.EntryBundleWidth + uint64(i) //nolint:gosec
+		hashes, err := proofBuilder.InclusionProof(ctx, leafIndex)
+		if err != nil {
+			return nil, fmt.Errorf("building inclusion proof for entry %d: %w", leafIndex, err)
+		}
+		if err := proof.VerifyInclusion(rfc6962.DefaultHasher, leafIndex, cp.Size, rfc6962.DefaultHasher.HashLeaf(body), hashes, cp.Hash); err != nil {
+			return nil, fmt.Errorf("verifying inclusion proof for entry %d: %w", leafIndex, err)
+		}
+		entry := &pb.Entry{}
+		if err := protojson.Unmarshal(body, entry); err != nil {
+			return nil, fmt.Errorf("decoding entry %d: %w", leafIndex, err)
+		}
+		entries[i] = &LogEntry{Index: leafIndex, Entry: entry, CanonicalizedBody: body}
+	}
+	return entries, nil
+}
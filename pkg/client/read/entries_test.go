@@ -0,0 +1,141 @@
+//
+// Copyright 2025 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package read
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/sigstore/rekor-tiles/v2/pkg/client"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/stretchr/testify/assert"
+)
+
+// tamperedBundles returns entry bundles with the last byte of each bundle flipped.
+type tamperedBundles struct {
+	Client
+}
+
+func (t tamperedBundles) ReadEntryBundle(ctx context.Context, index uint64, p uint8) ([]byte, error) {
+	bundle, err := t.Client.ReadEntryBundle(ctx, index, p)
+	if err == nil && len(bundle) > 0 {
+		bundle[len(bundle)-1] ^= 1
+	}
+	return bundle, err
+}
+
+func TestEntries(t *testing.T) {
+	origin := "rekor-local"
+	sv, _, err := signature.NewDefaultECDSASignerVerifier()
+	if err != nil {
+		t.Fatal(err)
+	}
+	log := newTestLog(t, origin, sv)
+	// Spans a full and a partial entry bundle
+	bodies := make([][]byte, 300)
+	for _, entry := range log.add(t, len(bodies)) {
+		bodies[entry.GetLogIndex()] = entry.GetCanonicalizedBody()
+	}
+	ctx := context.Background()
+
+	for _, maxFetches := range []int{0, 1, 3} {
+		reader, err := NewReader(log.server.URL, origin, sv, client.WithMaxConcurrentFetches(maxFetches))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, test := range []struct {
+			name       string
+			start, end uint64
+		}{
+			{name: "all", start: 0, end: 300},
+			{name: "full bundle", start: 0, end: 256},
+			{name: "partial bundle", start: 256, end: 300},
+			{name: "across bundles", start: 250, end: 260},
+			{name: "single entry", start: 299, end: 300},
+			{name: "empty", start: 10, end: 10},
+		} {
+			t.Run(test.name, func(t *testing.T) {
+				index := test.start
+				for entry, err := range reader.Entries(ctx, test.start, test.end) {
+					if !assert.NoError(t, err) {
+						return
+					}
+					assert.Equal(t, index, entry.Index)
+					assert.Equal(t, bodies[index], entry.CanonicalizedBody)
+					assert.Equal(t, "hashedrekord", entry.Entry.GetKind())
+					assert.NotEmpty(t, entry.Entry.GetSpec().GetHashedRekordV002().GetData().GetDigest())
+					index++
+				}
+				assert.Equal(t, test.end, index)
+			})
+		}
+	}
+
+	reader, err := NewReader(log.server.URL, origin, sv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("stop early", func(t *testing.T) {
+		var n int
+		for _, err := range reader.Entries(ctx, 0, 300) {
+			assert.NoError(t, err)
+			n++
+			if n == 5 {
+				break
+			}
+		}
+		assert.Equal(t, 5, n)
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+		for _, r := range [][2]uint64{{0, 301}, {5, 4}} {
+			var errs []error
+			for entry, err := range reader.Entries(ctx, r[0], r[1]) {
+				assert.Nil(t, entry)
+				errs = append(errs, err)
+			}
+			if assert.Len(t, errs, 1) {
+				assert.ErrorContains(t, errs[0], "out of range")
+			}
+		}
+	})
+
+	t.Run("tampered bundles", func(t *testing.T) {
+		var errs []error
+		for _, err := range entries(ctx, tamperedBundles{Client: reader}, 0, 300, 0) {
+			errs = append(errs, err)
+		}
+		if assert.Len(t, errs, 1) {
+			assert.ErrorContains(t, errs[0], "verifying inclusion proof")
+		}
+	})
+
+	t.Run("get entry", func(t *testing.T) {
+		for _, index := range []uint64{0, 255, 256, 299} {
+			entry, body, err := reader.GetEntry(ctx, index)
+			if assert.NoError(t, err) {
+				assert.True(t, bytes.Equal(bodies[index], body))
+				assert.Equal(t, "hashedrekord", entry.GetKind())
+			}
+		}
+		_, _, err := reader.GetEntry(ctx, 300)
+		assert.ErrorContains(t, err, "out of range")
+		_, err = getEntry(ctx, tamperedBundles{Client: reader}, 3)
+		assert.ErrorContains(t, err, "verifying inclusion proof")
+	})
+}
@@ -0,0 +1,192 @@
+//
+// Copyright 2025 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package read
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"os"
+	"time"
+
+	"github.com/sigstore/rekor-tiles/v2/pkg/client"
+	pb "github.com/sigstore/rekor-tiles/v2/pkg/generated/protobuf"
+	rekornote "github.com/sigstore/rekor-tiles/v2/pkg/note"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/transparency-dev/formats/log"
+	"github.com/transparency-dev/tessera/api/layout"
+	tclient "github.com/transparency-dev/tessera/client"
+	"golang.org/x/mod/sumdb/note"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// GRPCClient reads checkpoints, tiles, and entry bundles from the Rekor gRPC service,
+// and builds verified proofs and reads verified entries from them.
+// Close must be called to release the connection.
+type GRPCClient interface {
+	Reader
+	Close() error
+}
+
+type grpcReadClient struct {
+	conn       *grpc.ClientConn
+	client     pb.RekorClient
+	timeout    time.Duration
+	origin     string
+	verifier   note.Verifier
+	maxFetches int
+	tracker    *checkpointTracker
+	cache      client.TileCache
+}
+
+// NewGRPCReader creates a new reader client for the gRPC service at target, a host and port.
+// Connections use TLS unless the client is configured with client.WithInsecure.
+func NewGRPCReader(target, origin string, verifier signature.Verifier, opts ...client.Option) (GRPCClient, error) {
+	cfg := &client.Config{}
+	for _, o := range opts {
+		o(cfg)
+	}
+	noteVerifier, err := rekornote.NewNoteVerifier(origin, verifier)
+	if err != nil {
+		return nil, fmt.Errorf("creating note verifier: %w", err)
+	}
+	conn, err := client.NewGRPCConn(target, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &grpcReadClient{
+		conn:       conn,
+		client:     pb.NewRekorClient(conn),
+		timeout:    cfg.Timeout,
+		origin:     origin,
+		verifier:   noteVerifier,
+		maxFetches: cfg.MaxConcurrentFetches,
+		tracker:    newCheckpointTracker(cfg.CheckpointStore, origin, noteVerifier),
+		cache:      cfg.TileCache,
+	}, nil
+}
+
+// ReadCheckpoint returns the current checkpoint. If the client is configured with a
+// checkpoint store, the checkpoint must be consistent with the stored checkpoint.
+func (g *grpcReadClient) ReadCheckpoint(ctx context.Context) (*log.Checkpoint, *note.Note, error) {
+	readCheckpoint := func(ctx context.Context) ([]byte, error) {
+		ctx, cancel := g.withTimeout(ctx)
+		defer cancel()
+		body, err := g.client.GetCheckpoint(ctx, &emptypb.Empty{})
+		if err != nil {
+			return nil, readError(err)
+		}
+		return body.GetData(), nil
+	}
+	cp, raw, n, err := tclient.FetchCheckpoint(ctx, readCheckpoint, g.verifier, g.origin)
+	if err != nil {
+		return nil, nil, fmt.Errorf("fetching checkpoint: %w", err)
+	}
+	e := newEvictingReader(g, g.cache, g.origin)
+	if err := e.evict(ctx, g.tracker.update(ctx, e.ReadTile, raw, cp)); err != nil {
+		return nil, nil, err
+	}
+	return cp, n, nil
+}
+
+// ReadTile returns the tile at the given level, index, and tile segment. Full tiles
+// are read from the client's tile cache, if configured.
+func (g *grpcReadClient) ReadTile(ctx context.Context, level, index uint64, p uint8) ([]byte, error) {
+	return cachedRead(ctx, g.cache, g.origin, layout.TilePath(level, index, p), p, func() ([]byte, error) {
+		ctx, cancel := g.withTimeout(ctx)
+		defer cancel()
+		body, err := g.client.GetTile(ctx, &pb.TileRequest{L: uint32(level), N: layout.NWithSuffix(level, index, p)}) //nolint:gosec
+		if err != nil {
+			return nil, fmt.Errorf("reading tile: %w", readError(err))
+		}
+		return body.GetData(), nil
+	})
+}
+
+// ReadEntryBundle returns the entries at the given index. Full entry bundles are
+// read from the client's tile cache, if configured.
+func (g *grpcReadClient) ReadEntryBundle(ctx context.Context, index uint64, p uint8) ([]byte, error) {
+	return cachedRead(ctx, g.cache, g.origin, layout.EntriesPath(index, p), p, func() ([]byte, error) {
+		ctx, cancel := g.withTimeout(ctx)
+		defer cancel()
+		body, err := g.client.GetEntryBundle(ctx, &pb.EntryBundleRequest{N: layout.NWithSuffix(0, index, p)})
+		if err != nil {
+			return nil, fmt.Errorf("reading entry bundle: %w", readError(err))
+		}
+		return body.GetData(), nil
+	})
+}
+
+// InclusionProof returns the inclusion proof for the leaf at index in the tree of treeSize.
+func (g *grpcReadClient) InclusionProof(ctx context.Context, index, treeSize uint64) ([][]byte, error) {
+	e := newEvictingReader(g, g.cache, g.origin)
+	hashes, err := inclusionProof(ctx, e, index, treeSize)
+	return hashes, e.evict(ctx, err)
+}
+
+// ConsistencyProof returns the consistency proof between the trees of size from and to.
+func (g *grpcReadClient) ConsistencyProof(ctx context.Context, from, to uint64) ([][]byte, error) {
+	e := newEvictingReader(g, g.cache, g.origin)
+	hashes, err := consistencyProof(ctx, e, from, to)
+	return hashes, e.evict(ctx, err)
+}
+
+// LeafHashAt returns the Merkle leaf hash of the entry at index.
+func (g *grpcReadClient) LeafHashAt(ctx context.Context, index uint64) ([]byte, error) {
+	e := newEvictingReader(g, g.cache, g.origin)
+	leafHash, err := leafHashAt(ctx, e, index)
+	return leafHash, e.evict(ctx, err)
+}
+
+// GetEntry returns the decoded entry at index and its canonicalized body.
+func (g *grpcReadClient) GetEntry(ctx context.Context, index uint64) (*pb.Entry, []byte, error) {
+	e := newEvictingReader(g, g.cache, g.origin)
+	entry, err := getEntry(ctx, e, index)
+	if err := e.evict(ctx, err); err != nil {
+		return nil, nil, err
+	}
+	return entry.Entry, entry.CanonicalizedBody, nil
+}
+
+// Entries returns an iterator over the entries in [start, end).
+func (g *grpcReadClient) Entries(ctx context.Context, start, end uint64) iter.Seq2[*LogEntry, error] {
+	e := newEvictingReader(g, g.cache, g.origin)
+	return e.evictSeq(ctx, entries(ctx, e, start, end, g.maxFetches))
+}
+
+// Close closes the connection to the server.
+func (g *grpcReadClient) Close() error {
+	return g.conn.Close()
+}
+
+func (g *grpcReadClient) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if g.timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, g.timeout)
+}
+
+// readError wraps os.ErrNotExist for resources the server could not find, matching
+// the errors returned when reading from the tile storage service.
+func readError(err error) error {
+	if status.Code(err) == codes.NotFound {
+		return fmt.Errorf("%w: %s", os.ErrNotExist, status.Convert(err).Message())
+	}
+	return err
+}
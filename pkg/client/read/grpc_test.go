@@ -0,0 +1,112 @@
+//
+// Copyright 2025 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package read
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/sigstore/rekor-tiles/v2/pkg/client"
+	pb "github.com/sigstore/rekor-tiles/v2/pkg/generated/protobuf"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type fakeRekorServer struct {
+	pb.UnimplementedRekorServer
+	checkpoint []byte
+}
+
+func (f *fakeRekorServer) GetCheckpoint(_ context.Context, _ *emptypb.Empty) (*httpbody.HttpBody, error) {
+	return &httpbody.HttpBody{Data: f.checkpoint}, nil
+}
+
+func (f *fakeRekorServer) GetTile(_ context.Context, req *pb.TileRequest) (*httpbody.HttpBody, error) {
+	if req.GetN() == "999" {
+		return nil, status.Error(codes.NotFound, "tile not found")
+	}
+	return &httpbody.HttpBody{Data: []byte(fmt.Sprintf("tile:%d,%s", req.GetL(), req.GetN()))}, nil
+}
+
+func (f *fakeRekorServer) GetEntryBundle(_ context.Context, req *pb.EntryBundleRequest) (*httpbody.HttpBody, error) {
+	if req.GetN() == "999" {
+		return nil, status.Error(codes.NotFound, "entry bundle not found")
+	}
+	return &httpbody.HttpBody{Data: []byte("entries:" + req.GetN())}, nil
+}
+
+func startFakeServer(t *testing.T, fake *fakeRekorServer) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer()
+	pb.RegisterRekorServer(s, fake)
+	go func() { _ = s.Serve(lis) }()
+	t.Cleanup(s.Stop)
+	return lis.Addr().String()
+}
+
+func TestGRPCReader(t *testing.T) {
+	ctx := context.Background()
+	verifier, err := getVerifier(ed25519PrivKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fake := &fakeRekorServer{checkpoint: []byte(`rekor-local
+2
+vABc4Xj1G9UUySBRYDvTZpYtdDqbKN9XthAbY4Nqd/Y=
+
+— rekor-local 2AtEIJwBlAY6KMMNAqcWRKgPZDhP6/bpBmefw4mD89JwL3KozxrLgz7MA8G5pM4UrGNoTOxxpW2bbdv/A5l22ymMLAU=
+`)}
+	target := startFakeServer(t, fake)
+	reader, err := NewGRPCReader(target, "rekor-local", verifier, client.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+	assert.Implements(t, (*ProofClient)(nil), reader)
+	assert.Implements(t, (*EntryClient)(nil), reader)
+
+	cp, n, err := reader.ReadCheckpoint(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), cp.Size)
+	assert.NotNil(t, n)
+
+	tile, err := reader.ReadTile(ctx, 1, 123456, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("tile:1,x123/456.p/3"), tile)
+	_, err = reader.ReadTile(ctx, 0, 999, 0)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	bundle, err := reader.ReadEntryBundle(ctx, 1, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("entries:001"), bundle)
+	_, err = reader.ReadEntryBundle(ctx, 999, 0)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	fake.checkpoint = []byte("wrong-origin\n2\nvABc4Xj1G9UUySBRYDvTZpYtdDqbKN9XthAbY4Nqd/Y=\n")
+	_, _, err = reader.ReadCheckpoint(ctx)
+	assert.Error(t, err)
+}
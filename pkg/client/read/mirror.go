@@ -0,0 +1,172 @@
+//
+// Copyright 2025 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package read
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+	"time"
+
+	tclient "github.com/transparency-dev/tessera/client"
+)
+
+const (
+	// mirrorRetryAfter is how long a mirror is tried after others once a request to it fails.
+	mirrorRetryAfter = time.Minute
+	// defaultMirrorTimeout bounds each request to a single mirror, unless configured
+	// with client.WithMirrorTimeout.
+	defaultMirrorTimeout = 10 * time.Second
+)
+
+// mirror is a base URL serving the log's checkpoints, tiles and entry bundles.
+type mirror struct {
+	url     *url.URL
+	fetcher *tclient.HTTPFetcher
+
+	mu sync.Mutex
+	// latency is a moving average of the duration of successful requests, or zero
+	// if no request has succeeded
+	latency time.Duration
+	// failures is the number of consecutive failed requests
+	failures int
+	// failedAt is the time of the last failed request
+	failedAt time.Time
+}
+
+func (m *mirror) succeeded(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.failures = 0
+	if m.latency == 0 {
+		m.latency = d
+		return
+	}
+	m.latency = (3*m.latency + d) / 4
+}
+
+func (m *mirror) failed() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.failures++
+	m.failedAt = time.Now()
+}
+
+// rank returns the mirror's recent consecutive failures and latency.
+func (m *mirror) rank() (int, time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if time.Since(m.failedAt) > mirrorRetryAfter {
+		return 0, m.latency
+	}
+	return m.failures, m.latency
+}
+
+// mirrorSet fetches from an ordered list of mirrors, preferring the fastest mirror
+// and falling back to the next mirror when a request fails.
+type mirrorSet struct {
+	mirrors []*mirror
+	// timeout bounds each attempt with a single mirror, or is zero for no bound
+	timeout time.Duration
+}
+
+func newMirrorSet(urls []*url.URL, httpClient *http.Client, timeout time.Duration) (*mirrorSet, error) {
+	ms := &mirrorSet{timeout: max(timeout, 0)}
+	for _, u := range urls {
+		fetcher, err := tclient.NewHTTPFetcher(u, httpClient)
+		if err != nil {
+			return nil, fmt.Errorf("creating tile client for %s: %w", u, err)
+		}
+		ms.mirrors = append(ms.mirrors, &mirror{url: u, fetcher: fetcher})
+	}
+	return ms, nil
+}
+
+// ordered returns the mirrors in the order they should be tried. Mirrors with fewer
+// recent consecutive failures come first, then faster mirrors. Mirrors that have not yet
+// been measured are tried before measured ones, so that every mirror's latency is
+// eventually known, and ties keep the configured order.
+func (ms *mirrorSet) ordered() []*mirror {
+	type ranked struct {
+		m        *mirror
+		failures int
+		latency  time.Duration
+	}
+	rs := make([]ranked, len(ms.mirrors))
+	for i, m := range ms.mirrors {
+		failures, latency := m.rank()
+		rs[i] = ranked{m: m, failures: failures, latency: latency}
+	}
+	slices.SortStableFunc(rs, func(a, b ranked) int {
+		if a.failures != b.failures {
+			return a.failures - b.failures
+		}
+		return cmp.Compare(a.latency, b.latency)
+	})
+	ordered := make([]*mirror, len(rs))
+	for i, r := range rs {
+		ordered[i] = r.m
+	}
+	return ordered
+}
+
+// fetch calls f with each mirror's fetcher in order until it succeeds, returning
+// the errors from every mirror if none succeed. Each call is given a context that
+// expires after the mirror set's timeout, after which the next mirror is tried.
+func fetch[T any](ctx context.Context, ms *mirrorSet, f func(context.Context, *tclient.HTTPFetcher) (T, error)) (T, error) {
+	return fetchUntil(ctx, ms, f, nil)
+}
+
+// fetchUntil is like fetch, but returns an error from f immediately if stop returns
+// true for it, rather than falling back to the next mirror.
+func fetchUntil[T any](ctx context.Context, ms *mirrorSet, f func(context.Context, *tclient.HTTPFetcher) (T, error), stop func(error) bool) (T, error) {
+	var errs []error
+	for _, m := range ms.ordered() {
+		start := time.Now()
+		v, err := attempt(ctx, ms, m, f)
+		if err == nil {
+			m.succeeded(time.Since(start))
+			return v, nil
+		}
+		if ctx.Err() != nil || (stop != nil && stop(err)) {
+			var zero T
+			return zero, err
+		}
+		m.failed()
+		if len(ms.mirrors) == 1 {
+			errs = append(errs, err)
+		} else {
+			errs = append(errs, fmt.Errorf("%s: %w", m.url, err))
+		}
+	}
+	var zero T
+	return zero, errors.Join(errs...)
+}
+
+// attempt calls f with the mirror's fetcher and a context bounded by the mirror set's timeout.
+func attempt[T any](ctx context.Context, ms *mirrorSet, m *mirror, f func(context.Context, *tclient.HTTPFetcher) (T, error)) (T, error) {
+	if ms.timeout == 0 {
+		return f(ctx, m.fetcher)
+	}
+	ctx, cancel := context.WithTimeout(ctx, ms.timeout)
+	defer cancel()
+	return f(ctx, m.fetcher)
+}
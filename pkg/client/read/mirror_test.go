@@ -0,0 +1,191 @@
+//
+// Copyright 2025 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package read
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sigstore/rekor-tiles/v2/pkg/client"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/stretchr/testify/assert"
+)
+
+// countingServer serves handler, counting requests.
+func countingServer(t *testing.T, handler http.Handler) (*httptest.Server, *atomic.Int64) {
+	var n atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n.Add(1)
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return server, &n
+}
+
+// proxy forwards requests to target.
+func proxy(t *testing.T, target string) http.Handler {
+	u, err := url.Parse(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return httputil.NewSingleHostReverseProxy(u)
+}
+
+func TestMirrors(t *testing.T) {
+	origin := "rekor-local"
+	sv, _, err := signature.NewDefaultECDSASignerVerifier()
+	if err != nil {
+		t.Fatal(err)
+	}
+	log := newTestLog(t, origin, sv)
+	log.add(t, 5)
+	ctx := context.Background()
+
+	// The mirror serves the log's files
+	mirror, mirrorRequests := countingServer(t, http.StripPrefix("/mirror", proxy(t, log.server.URL)))
+	down, downRequests := countingServer(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	// The forged log serves a checkpoint signed by another key, and tiles of another tree
+	otherSigner, _, err := signature.NewDefaultECDSASignerVerifier()
+	if err != nil {
+		t.Fatal(err)
+	}
+	forged := newTestLog(t, origin, otherSigner)
+	forged.add(t, 5)
+
+	t.Run("fall back from unavailable mirror", func(t *testing.T) {
+		reader, err := NewReader(down.URL, origin, sv, client.WithMirrors(mirror.URL+"/mirror/"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for range 3 {
+			cp, _, err := reader.ReadCheckpoint(ctx)
+			if assert.NoError(t, err) {
+				assert.Equal(t, uint64(5), cp.Size)
+			}
+		}
+		_, err = reader.ConsistencyProof(ctx, 2, 5)
+		assert.NoError(t, err)
+		// After the first failure, requests go to the mirror first
+		assert.Equal(t, int64(1), downRequests.Load())
+		assert.Positive(t, mirrorRequests.Load())
+	})
+
+	t.Run("fall back from hanging mirror", func(t *testing.T) {
+		hang := make(chan struct{})
+		hanging, hangingRequests := countingServer(t, http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			select {
+			case <-hang:
+			case <-r.Context().Done():
+			}
+		}))
+		defer close(hang)
+		reader, err := NewReader(hanging.URL, origin, sv, client.WithMirrors(log.server.URL), client.WithMirrorTimeout(100*time.Millisecond))
+		if err != nil {
+			t.Fatal(err)
+		}
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		cp, _, err := reader.ReadCheckpoint(ctx)
+		if assert.NoError(t, err) {
+			assert.Equal(t, uint64(5), cp.Size)
+		}
+		assert.Equal(t, int64(1), hangingRequests.Load())
+		assert.NoError(t, ctx.Err())
+	})
+
+	t.Run("skip forged checkpoint", func(t *testing.T) {
+		reader, err := NewReader(forged.server.URL, origin, sv, client.WithMirrors(log.server.URL))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _, err = reader.ReadCheckpoint(ctx)
+		assert.NoError(t, err)
+	})
+
+	t.Run("forged tiles are not trusted", func(t *testing.T) {
+		// The forged log's tiles are served for the trusted log's checkpoint
+		mux := http.NewServeMux()
+		mux.Handle("/checkpoint", proxy(t, log.server.URL))
+		mux.Handle("/", proxy(t, forged.server.URL))
+		mixed, _ := countingServer(t, mux)
+		reader, err := NewReader(mixed.URL, origin, sv)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = reader.InclusionProof(ctx, 1, 3)
+		assert.Error(t, err)
+		_, _, err = reader.GetEntry(ctx, 1)
+		assert.Error(t, err)
+	})
+
+	t.Run("all mirrors unavailable", func(t *testing.T) {
+		other, _ := countingServer(t, http.NotFoundHandler())
+		reader, err := NewReader(down.URL, origin, sv, client.WithMirrors(other.URL))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _, err = reader.ReadCheckpoint(ctx)
+		assert.ErrorContains(t, err, down.URL)
+		assert.ErrorContains(t, err, other.URL)
+	})
+}
+
+func TestMirrorOrder(t *testing.T) {
+	var urls []*url.URL
+	for _, u := range []string{"https://a.example", "https://b.example", "https://c.example", "https://d.example"} {
+		parsed, err := url.Parse(u)
+		if err != nil {
+			t.Fatal(err)
+		}
+		urls = append(urls, parsed)
+	}
+	ms, err := newMirrorSet(urls, http.DefaultClient, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	order := func() []string {
+		var hosts []string
+		for _, m := range ms.ordered() {
+			hosts = append(hosts, m.url.Host)
+		}
+		return hosts
+	}
+	a, b, c, d := ms.mirrors[0], ms.mirrors[1], ms.mirrors[2], ms.mirrors[3]
+
+	assert.Equal(t, []string{"a.example", "b.example", "c.example", "d.example"}, order())
+	a.succeeded(100 * time.Millisecond)
+	b.succeeded(10 * time.Millisecond)
+	c.succeeded(50 * time.Millisecond)
+	// d is unmeasured and tried first
+	assert.Equal(t, []string{"d.example", "b.example", "c.example", "a.example"}, order())
+	d.succeeded(200 * time.Millisecond)
+	b.failed()
+	assert.Equal(t, []string{"c.example", "a.example", "d.example", "b.example"}, order())
+	// Failures are forgotten after a while
+	b.failedAt = time.Now().Add(-2 * mirrorRetryAfter)
+	assert.Equal(t, []string{"b.example", "c.example", "a.example", "d.example"}, order())
+	b.failed()
+	b.succeeded(10 * time.Millisecond)
+	assert.Equal(t, "b.example", order()[0])
+}
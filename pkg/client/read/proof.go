@@ -0,0 +1,186 @@
+//
+// Copyright 2025 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package read
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/transparency-dev/formats/log"
+	"github.com/transparency-dev/merkle/compact"
+	"github.com/transparency-dev/merkle/proof"
+	"github.com/transparency-dev/merkle/rfc6962"
+	"github.com/transparency-dev/tessera/api/layout"
+	tclient "github.com/transparency-dev/tessera/client"
+	"golang.org/x/mod/sumdb/note"
+)
+
+// tileReader reads verified checkpoints and tiles, from which proofs are built.
+type tileReader interface {
+	ReadCheckpoint(context.Context) (*log.Checkpoint, *note.Note, error)
+	ReadTile(context.Context, uint64, uint64, uint8) ([]byte, error)
+}
+
+// inclusionProof returns the inclusion proof for the leaf at index in the tree of
+// treeSize, verified against the tree's root hash.
+func inclusionProof(ctx context.Context, r tileReader, index, treeSize uint64) ([][]byte, error) {
+	cp, _, err := r.ReadCheckpoint(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if index >= treeSize || treeSize > cp.Size {
+		return nil, fmt.Errorf("index %d and tree size %d out of range for checkpoint size %d", index, treeSize, cp.Size)
+	}
+	root, err := verifiedRoot(ctx, r, cp, treeSize)
+	if err != nil {
+		return nil, err
+	}
+	fetcher := tilesAt(r, cp.Size)
+	proofBuilder, err := tclient.NewProofBuilder(ctx, treeSize, fetcher)
+	if err != nil {
+		return nil, fmt.Errorf("creating proof builder: %w", err)
+	}
+	hashes, err := proofBuilder.InclusionProof(ctx, index)
+	if err != nil {
+		return nil, fmt.Errorf("building inclusion proof: %w", err)
+	}
+	leafHashes, err := tclient.FetchLeafHashes(ctx, fetcher, index, 1, treeSize)
+	if err != nil {
+		return nil, fmt.Errorf("fetching leaf hash: %w", err)
+	}
+	if err := proof.VerifyInclusion(rfc6962.DefaultHasher, index, treeSize, leafHashes[0], hashes, root); err != nil {
+		return nil, fmt.Errorf("verifying inclusion proof: %w", err)
+	}
+	return hashes, nil
+}
+
+// consistencyProof returns the consistency proof between the trees of size from and
+// to, verified against their root hashes.
+func consistencyProof(ctx context.Context, r tileReader, from, to uint64) ([][]byte, error) {
+	cp, _, err := r.ReadCheckpoint(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if from > to || to > cp.Size {
+		return nil, fmt.Errorf("tree sizes %d and %d out of range for checkpoint size %d", from, to, cp.Size)
+	}
+	fromRoot, err := verifiedRoot(ctx, r, cp, from)
+	if err != nil {
+		return nil, err
+	}
+	toRoot, err := verifiedRoot(ctx, r, cp, to)
+	if err != nil {
+		return nil, err
+	}
+	proofBuilder, err := tclient.NewProofBuilder(ctx, to, tilesAt(r, cp.Size))
+	if err != nil {
+		return nil, fmt.Errorf("creating proof builder: %w", err)
+	}
+	hashes, err := proofBuilder.ConsistencyProof(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("building consistency proof: %w", err)
+	}
+	if err := proof.VerifyConsistency(rfc6962.DefaultHasher, from, to, hashes, fromRoot, toRoot); err != nil {
+		return nil, fmt.Errorf("verifying consistency proof: %w", err)
+	}
+	return hashes, nil
+}
+
+// leafHashAt returns the hash of the leaf at index, verified by an inclusion proof
+// against the latest checkpoint.
+func leafHashAt(ctx context.Context, r tileReader, index uint64) ([]byte, error) {
+	cp, _, err := r.ReadCheckpoint(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if index >= cp.Size {
+		return nil, fmt.Errorf("index %d out of range for checkpoint size %d", index, cp.Size)
+	}
+	leafHashes, err := tclient.FetchLeafHashes(ctx, r.ReadTile, index, 1, cp.Size)
+	if err != nil {
+		return nil, fmt.Errorf("fetching leaf hash: %w", err)
+	}
+	proofBuilder, err := tclient.NewProofBuilder(ctx, cp.Size, r.ReadTile)
+	if err != nil {
+		return nil, fmt.Errorf("creating proof builder: %w", err)
+	}
+	hashes, err := proofBuilder.InclusionProof(ctx, index)
+	if err != nil {
+		return nil, fmt.Errorf("building inclusion proof: %w", err)
+	}
+	if err := proof.VerifyInclusion(rfc6962.DefaultHasher, index, cp.Size, leafHashes[0], hashes, cp.Hash); err != nil {
+		return nil, fmt.Errorf("verifying inclusion proof: %w", err)
+	}
+	return leafHashes[0], nil
+}
+
+// verifiedRoot returns the root hash of the tree of the given size. Unless size is
+// the checkpoint's size, the root hash is computed from tiles and proven to be
+// consistent with the verified checkpoint.
+func verifiedRoot(ctx context.Context, r tileReader, cp *log.Checkpoint, size uint64) ([]byte, error) {
+	if size == cp.Size {
+		return cp.Hash, nil
+	}
+	if size == 0 {
+		return rfc6962.DefaultHasher.EmptyRoot(), nil
+	}
+	nodes, err := tclient.FetchRangeNodes(ctx, size, tilesAt(r, cp.Size))
+	if err != nil {
+		return nil, fmt.Errorf("fetching nodes for tree size %d: %w", size, err)
+	}
+	rf := compact.RangeFactory{Hash: rfc6962.DefaultHasher.HashChildren}
+	rng, err := rf.NewRange(0, size, nodes)
+	if err != nil {
+		return nil, fmt.Errorf("computing root for tree size %d: %w", size, err)
+	}
+	root, err := rng.GetRootHash(nil)
+	if err != nil {
+		return nil, fmt.Errorf("computing root for tree size %d: %w", size, err)
+	}
+	proofBuilder, err := tclient.NewProofBuilder(ctx, cp.Size, r.ReadTile)
+	if err != nil {
+		return nil, fmt.Errorf("creating proof builder: %w", err)
+	}
+	hashes, err := proofBuilder.ConsistencyProof(ctx, size, cp.Size)
+	if err != nil {
+		return nil, fmt.Errorf("building consistency proof: %w", err)
+	}
+	if err := proof.VerifyConsistency(rfc6962.DefaultHasher, size, cp.Size, hashes, root, cp.Hash); err != nil {
+		return nil, fmt.Errorf("verifying root for tree size %d: %w", size, err)
+	}
+	return root, nil
+}
+
+// tilesAt returns a tile fetcher for trees no larger than logSize. Partial tiles are
+// only published for the sizes of checkpoints, so tiles are read as published for
+// logSize and truncated to the requested width.
+func tilesAt(r tileReader, logSize uint64) tclient.TileFetcherFunc {
+	return func(ctx context.Context, level, index uint64, p uint8) ([]byte, error) {
+		tile, err := r.ReadTile(ctx, level, index, layout.PartialTileSize(level, index, logSize))
+		if err != nil {
+			return nil, err
+		}
+		width := layout.TileWidth
+		if p > 0 {
+			width = int(p)
+		}
+		if len(tile) < width*sha256.Size {
+			return nil, fmt.Errorf("tile %d/%d has %d bytes, want at least %d", level, index, len(tile), width*sha256.Size)
+		}
+		return tile[:width*sha256.Size], nil
+	}
+}
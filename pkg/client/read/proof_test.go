@@ -0,0 +1,128 @@
+//
+// Copyright 2025 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package read
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/stretchr/testify/assert"
+	"github.com/transparency-dev/merkle/proof"
+	"github.com/transparency-dev/merkle/rfc6962"
+)
+
+// tamperedTiles returns tiles with the first byte of each level 0 tile flipped.
+type tamperedTiles struct {
+	Client
+}
+
+func (t tamperedTiles) ReadTile(ctx context.Context, level, index uint64, p uint8) ([]byte, error) {
+	tile, err := t.Client.ReadTile(ctx, level, index, p)
+	if err == nil && level == 0 && len(tile) > 0 {
+		tile[0] ^= 1
+	}
+	return tile, err
+}
+
+func TestProofs(t *testing.T) {
+	origin := "rekor-local"
+	sv, _, err := signature.NewDefaultECDSASignerVerifier()
+	if err != nil {
+		t.Fatal(err)
+	}
+	log := newTestLog(t, origin, sv)
+	entries := log.add(t, 5)
+	entries = append(entries, log.add(t, 5)...)
+	reader, err := NewReader(log.server.URL, origin, sv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	t.Run("leaf hash", func(t *testing.T) {
+		for _, entry := range entries {
+			leafHash, err := reader.LeafHashAt(ctx, uint64(entry.GetLogIndex())) //nolint:gosec
+			if assert.NoError(t, err) {
+				assert.Equal(t, rfc6962.DefaultHasher.HashLeaf(entry.GetCanonicalizedBody()), leafHash)
+			}
+		}
+		_, err := reader.LeafHashAt(ctx, 10)
+		assert.ErrorContains(t, err, "out of range")
+	})
+
+	t.Run("inclusion proof", func(t *testing.T) {
+		for _, entry := range entries {
+			index := uint64(entry.GetLogIndex())                        //nolint:gosec
+			treeSize := uint64(entry.GetInclusionProof().GetTreeSize()) //nolint:gosec
+			// The proof against the entry's checkpoint must match the one returned when the entry was added
+			hashes, err := reader.InclusionProof(ctx, index, treeSize)
+			if assert.NoError(t, err) {
+				assert.Equal(t, entry.GetInclusionProof().GetHashes(), hashes)
+			}
+			hashes, err = reader.InclusionProof(ctx, index, 10)
+			if assert.NoError(t, err) {
+				cp, _, err := reader.ReadCheckpoint(ctx)
+				if err != nil {
+					t.Fatal(err)
+				}
+				assert.NoError(t, proof.VerifyInclusion(rfc6962.DefaultHasher, index, 10, rfc6962.DefaultHasher.HashLeaf(entry.GetCanonicalizedBody()), hashes, cp.Hash))
+			}
+		}
+		// Tree sizes without a published checkpoint are built from truncated tiles
+		_, err := reader.InclusionProof(ctx, 2, 7)
+		assert.NoError(t, err)
+		_, err = reader.InclusionProof(ctx, 5, 5)
+		assert.ErrorContains(t, err, "out of range")
+		_, err = reader.InclusionProof(ctx, 0, 11)
+		assert.ErrorContains(t, err, "out of range")
+	})
+
+	t.Run("consistency proof", func(t *testing.T) {
+		for _, from := range entries {
+			for _, to := range entries {
+				fromSize, toSize := uint64(from.GetInclusionProof().GetTreeSize()), uint64(to.GetInclusionProof().GetTreeSize()) //nolint:gosec
+				if fromSize > toSize {
+					continue
+				}
+				hashes, err := reader.ConsistencyProof(ctx, fromSize, toSize)
+				if assert.NoError(t, err) {
+					assert.NoError(t, proof.VerifyConsistency(rfc6962.DefaultHasher, fromSize, toSize, hashes,
+						from.GetInclusionProof().GetRootHash(), to.GetInclusionProof().GetRootHash()))
+				}
+			}
+		}
+		_, err := reader.ConsistencyProof(ctx, 3, 7)
+		assert.NoError(t, err)
+		hashes, err := reader.ConsistencyProof(ctx, 0, 10)
+		assert.NoError(t, err)
+		assert.Empty(t, hashes)
+		_, err = reader.ConsistencyProof(ctx, 5, 4)
+		assert.ErrorContains(t, err, "out of range")
+		_, err = reader.ConsistencyProof(ctx, 5, 11)
+		assert.ErrorContains(t, err, "out of range")
+	})
+
+	t.Run("tampered tiles", func(t *testing.T) {
+		tampered := tamperedTiles{Client: reader}
+		_, err := leafHashAt(ctx, tampered, 3)
+		assert.ErrorContains(t, err, "verifying inclusion proof")
+		_, err = inclusionProof(ctx, tampered, 3, 7)
+		assert.ErrorContains(t, err, "verifying root")
+		_, err = consistencyProof(ctx, tampered, 3, 7)
+		assert.ErrorContains(t, err, "verifying root")
+	})
+}
@@ -17,14 +17,18 @@ package read
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 
 	"github.com/sigstore/rekor-tiles/v2/pkg/client"
+	pb "github.com/sigstore/rekor-tiles/v2/pkg/generated/protobuf"
 	rekornote "github.com/sigstore/rekor-tiles/v2/pkg/note"
 	"github.com/sigstore/sigstore/pkg/signature"
 	"github.com/transparency-dev/formats/log"
+	"github.com/transparency-dev/tessera/api/layout"
 	tclient "github.com/transparency-dev/tessera/client"
 	"golang.org/x/mod/sumdb/note"
 )
@@ -36,67 +40,182 @@ type Client interface {
 	ReadEntryBundle(context.Context, uint64, uint8) ([]byte, error)
 }
 
+// ProofClient builds proofs and leaf hashes from tiles. They are verified against the
+// latest checkpoint, which is verified with the client's verifier.
+type ProofClient interface {
+	Client
+	// InclusionProof returns the inclusion proof for the leaf at index in the tree of treeSize.
+	InclusionProof(ctx context.Context, index, treeSize uint64) ([][]byte, error)
+	// ConsistencyProof returns the consistency proof between the trees of size from and to.
+	ConsistencyProof(ctx context.Context, from, to uint64) ([][]byte, error)
+	// LeafHashAt returns the Merkle leaf hash of the entry at index.
+	LeafHashAt(ctx context.Context, index uint64) ([]byte, error)
+}
+
+// EntryClient reads decoded entries from entry bundles, verifying each entry's inclusion
+// in the latest checkpoint.
+type EntryClient interface {
+	Client
+	// GetEntry returns the decoded entry at index and its canonicalized body.
+	GetEntry(ctx context.Context, index uint64) (*pb.Entry, []byte, error)
+	// Entries returns an iterator over the entries in [start, end), stopping after the first error.
+	Entries(ctx context.Context, start, end uint64) iter.Seq2[*LogEntry, error]
+}
+
+// Reader is a Client that also builds verified proofs and reads verified entries.
+// NewReader returns a Reader.
+type Reader interface {
+	ProofClient
+	EntryClient
+}
+
 type readClient struct {
-	baseURL  *url.URL
-	client   *tclient.HTTPFetcher
-	origin   string
-	verifier note.Verifier
+	baseURL    *url.URL
+	mirrors    *mirrorSet
+	origin     string
+	verifier   note.Verifier
+	maxFetches int
+	tracker    *checkpointTracker
+	cache      client.TileCache
 }
 
-// NewReader creates a new reader client.
-func NewReader(readURL, origin string, verifier signature.Verifier, opts ...client.Option) (Client, error) {
+// NewReader creates a new reader client. Requests are sent to readURL, or to mirrors
+// configured with client.WithMirrors.
+func NewReader(readURL, origin string, verifier signature.Verifier, opts ...client.Option) (Reader, error) {
 	cfg := &client.Config{}
 	for _, o := range opts {
 		o(cfg)
 	}
-	baseURL, err := url.Parse(readURL)
-	if err != nil {
-		return nil, fmt.Errorf("parsing url %s: %w", readURL, err)
+	var urls []*url.URL
+	for _, u := range append([]string{readURL}, cfg.Mirrors...) {
+		parsed, err := url.Parse(u)
+		if err != nil {
+			return nil, fmt.Errorf("parsing url %s: %w", u, err)
+		}
+		urls = append(urls, parsed)
 	}
 	noteVerifier, err := rekornote.NewNoteVerifier(origin, verifier)
 	if err != nil {
 		return nil, fmt.Errorf("creating note verifier: %w", err)
 	}
 	httpClient := &http.Client{
-		Transport: client.CreateRoundTripper(http.DefaultTransport, cfg.UserAgent),
+		Transport: client.CreateTransport(cfg),
 		Timeout:   cfg.Timeout,
 	}
-	tileClient, err := tclient.NewHTTPFetcher(baseURL, httpClient)
+	mirrorTimeout := defaultMirrorTimeout
+	if cfg.MirrorTimeout != 0 {
+		mirrorTimeout = cfg.MirrorTimeout
+	}
+	mirrors, err := newMirrorSet(urls, httpClient, mirrorTimeout)
 	if err != nil {
-		return nil, fmt.Errorf("creating tile client: %w", err)
+		return nil, err
 	}
 	return &readClient{
-		baseURL:  baseURL,
-		client:   tileClient,
-		origin:   origin,
-		verifier: noteVerifier,
+		baseURL:    urls[0],
+		mirrors:    mirrors,
+		origin:     origin,
+		verifier:   noteVerifier,
+		maxFetches: cfg.MaxConcurrentFetches,
+		tracker:    newCheckpointTracker(cfg.CheckpointStore, origin, noteVerifier),
+		cache:      cfg.TileCache,
 	}, nil
 }
 
-// ReadCheckpoint returns the current checkpoint.
+// fetchedCheckpoint is a checkpoint fetched and verified by FetchCheckpoint.
+type fetchedCheckpoint struct {
+	cp   *log.Checkpoint
+	raw  []byte
+	note *note.Note
+}
+
+// ReadCheckpoint returns the current checkpoint. If the client is configured with a
+// checkpoint store, the checkpoint must be consistent with the stored checkpoint.
+// A mirror serving a checkpoint that fails verification is skipped, as is a mirror
+// serving a checkpoint older than the stored checkpoint, such as a stale CDN copy.
+// An InconsistentCheckpointError wrapping ErrCheckpointRollback is only returned if
+// every mirror serves an older checkpoint.
 func (r *readClient) ReadCheckpoint(ctx context.Context) (*log.Checkpoint, *note.Note, error) {
-	readCheckpoint := r.client.ReadCheckpoint
-	cp, _, n, err := tclient.FetchCheckpoint(ctx, readCheckpoint, r.verifier, r.origin)
+	fetched, err := fetchUntil(ctx, r.mirrors, func(ctx context.Context, f *tclient.HTTPFetcher) (fetchedCheckpoint, error) {
+		cp, raw, n, err := tclient.FetchCheckpoint(ctx, f.ReadCheckpoint, r.verifier, r.origin)
+		if err != nil {
+			return fetchedCheckpoint{}, err
+		}
+		e := newEvictingReader(r, r.cache, r.origin)
+		if err := e.evict(ctx, r.tracker.update(ctx, e.ReadTile, raw, cp)); err != nil {
+			return fetchedCheckpoint{}, err
+		}
+		return fetchedCheckpoint{cp: cp, raw: raw, note: n}, nil
+	}, func(err error) bool {
+		// A split view is evidence of the log's misbehavior, whichever mirror served it
+		return errors.Is(err, ErrSplitView)
+	})
 	if err != nil {
 		return nil, nil, fmt.Errorf("fetching checkpoint: %w", err)
 	}
-	return cp, n, nil
+	return fetched.cp, fetched.note, nil
 }
 
-// ReadTile returns the tile at the given level, index, and tile segment.
+// ReadTile returns the tile at the given level, index, and tile segment. Full tiles
+// are read from the client's tile cache, if configured.
 func (r *readClient) ReadTile(ctx context.Context, level, index uint64, p uint8) ([]byte, error) {
-	tile, err := r.client.ReadTile(ctx, level, index, p)
+	tile, err := cachedRead(ctx, r.cache, r.origin, layout.TilePath(level, index, p), p, func() ([]byte, error) {
+		return fetch(ctx, r.mirrors, func(ctx context.Context, f *tclient.HTTPFetcher) ([]byte, error) {
+			return f.ReadTile(ctx, level, index, p)
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("reading tile: %w", err)
 	}
 	return tile, nil
 }
 
-// ReadEntryBundle returns the entries at the given index.
+// ReadEntryBundle returns the entries at the given index. Full entry bundles are
+// read from the client's tile cache, if configured.
 func (r *readClient) ReadEntryBundle(ctx context.Context, index uint64, p uint8) ([]byte, error) {
-	bundle, err := r.client.ReadEntryBundle(ctx, index, p)
+	bundle, err := cachedRead(ctx, r.cache, r.origin, layout.EntriesPath(index, p), p, func() ([]byte, error) {
+		return fetch(ctx, r.mirrors, func(ctx context.Context, f *tclient.HTTPFetcher) ([]byte, error) {
+			return f.ReadEntryBundle(ctx, index, p)
+		})
+	})
 	if err != nil {
 		return nil, fmt.Errorf("reading entry bundle: %w", err)
 	}
 	return bundle, nil
 }
+
+// InclusionProof returns the inclusion proof for the leaf at index in the tree of treeSize.
+func (r *readClient) InclusionProof(ctx context.Context, index, treeSize uint64) ([][]byte, error) {
+	e := newEvictingReader(r, r.cache, r.origin)
+	hashes, err := inclusionProof(ctx, e, index, treeSize)
+	return hashes, e.evict(ctx, err)
+}
+
+// ConsistencyProof returns the consistency proof between the trees of size from and to.
+func (r *readClient) ConsistencyProof(ctx context.Context, from, to uint64) ([][]byte, error) {
+	e := newEvictingReader(r, r.cache, r.origin)
+	hashes, err := consistencyProof(ctx, e, from, to)
+	return hashes, e.evict(ctx, err)
+}
+
+// LeafHashAt returns the Merkle leaf hash of the entry at index.
+func (r *readClient) LeafHashAt(ctx context.Context, index uint64) ([]byte, error) {
+	e := newEvictingReader(r, r.cache, r.origin)
+	leafHash, err := leafHashAt(ctx, e, index)
+	return leafHash, e.evict(ctx, err)
+}
+
+// GetEntry returns the decoded entry at index and its canonicalized body.
+func (r *readClient) GetEntry(ctx context.Context, index uint64) (*pb.Entry, []byte, error) {
+	e := newEvictingReader(r, r.cache, r.origin)
+	entry, err := getEntry(ctx, e, index)
+	if err := e.evict(ctx, err); err != nil {
+		return nil, nil, err
+	}
+	return entry.Entry, entry.CanonicalizedBody, nil
+}
+
+// Entries returns an iterator over the entries in [start, end).
+func (r *readClient) Entries(ctx context.Context, start, end uint64) iter.Seq2[*LogEntry, error] {
+	e := newEvictingReader(r, r.cache, r.origin)
+	return e.evictSeq(ctx, entries(ctx, e, start, end, r.maxFetches))
+}
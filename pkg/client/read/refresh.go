@@ -0,0 +1,106 @@
+//
+// Copyright 2025 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package read
+
+import (
+	"context"
+	"fmt"
+
+	pbs "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
+	"github.com/sigstore/rekor-tiles/v2/pkg/verify"
+	"github.com/transparency-dev/formats/log"
+	tclient "github.com/transparency-dev/tessera/client"
+	"golang.org/x/mod/sumdb/note"
+	"google.golang.org/protobuf/proto"
+)
+
+// RefreshedProof is an inclusion proof for a log entry against a newer checkpoint.
+type RefreshedProof struct {
+	// Entry is a copy of the log entry with an inclusion proof against Checkpoint
+	Entry *pbs.TransparencyLogEntry
+	// Checkpoint is the verified newer checkpoint
+	Checkpoint *log.Checkpoint
+	// OldCheckpoint is the verified checkpoint of the original inclusion proof
+	OldCheckpoint *log.Checkpoint
+	// ConsistencyProof proves that the tree of OldCheckpoint is a prefix of the tree of Checkpoint
+	ConsistencyProof [][]byte
+}
+
+// RefreshInclusionProof returns a new inclusion proof for the entry against the
+// latest checkpoint read by c, along with a consistency proof between the checkpoint
+// of the entry's inclusion proof and the latest checkpoint. The verifier must be able
+// to verify both checkpoints. The entry must include its canonicalized body, and its
+// inclusion proof, the new inclusion proof and the consistency proof are all verified.
+func RefreshInclusionProof(ctx context.Context, c Client, entry *pbs.TransparencyLogEntry, verifier note.Verifier) (*RefreshedProof, error) {
+	if len(entry.GetCanonicalizedBody()) == 0 {
+		return nil, fmt.Errorf("entry is missing its canonicalized body")
+	}
+	if err := verify.VerifyLogEntry(entry, verifier); err != nil {
+		return nil, fmt.Errorf("verifying entry: %w", err)
+	}
+	oldCp, err := verify.VerifyCheckpoint(entry.GetInclusionProof().GetCheckpoint().GetEnvelope(), verifier)
+	if err != nil {
+		return nil, err
+	}
+	newCp, newNote, err := c.ReadCheckpoint(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if newCp.Size < oldCp.Size {
+		return nil, fmt.Errorf("latest checkpoint size %d is smaller than entry checkpoint size %d", newCp.Size, oldCp.Size)
+	}
+	proofBuilder, err := tclient.NewProofBuilder(ctx, newCp.Size, c.ReadTile)
+	if err != nil {
+		return nil, fmt.Errorf("creating proof builder: %w", err)
+	}
+	index := uint64(entry.GetLogIndex()) //nolint:gosec
+	inclusionProof, err := proofBuilder.InclusionProof(ctx, index)
+	if err != nil {
+		return nil, fmt.Errorf("building inclusion proof: %w", err)
+	}
+	consistencyProof, err := proofBuilder.ConsistencyProof(ctx, oldCp.Size, newCp.Size)
+	if err != nil {
+		return nil, fmt.Errorf("building consistency proof: %w", err)
+	}
+
+	// Signing with no signers encodes the note with its existing signatures, including
+	// those that were not verified, such as witness cosignatures
+	signed, err := note.Sign(newNote)
+	if err != nil {
+		return nil, fmt.Errorf("encoding checkpoint: %w", err)
+	}
+	envelope := string(signed)
+	refreshed := proto.Clone(entry).(*pbs.TransparencyLogEntry)
+	refreshed.InclusionProof = &pbs.InclusionProof{
+		LogIndex:   entry.GetLogIndex(),
+		RootHash:   newCp.Hash,
+		TreeSize:   int64(newCp.Size), //nolint:gosec
+		Hashes:     inclusionProof,
+		Checkpoint: &pbs.Checkpoint{Envelope: envelope},
+	}
+	if err := verify.VerifyLogEntry(refreshed, verifier); err != nil {
+		return nil, fmt.Errorf("verifying refreshed inclusion proof: %w", err)
+	}
+	if err := verify.VerifyConsistencyProof(consistencyProof, oldCp.Size, oldCp.Hash, envelope, verifier); err != nil {
+		return nil, fmt.Errorf("verifying consistency proof: %w", err)
+	}
+	return &RefreshedProof{
+		Entry:            refreshed,
+		Checkpoint:       newCp,
+		OldCheckpoint:    oldCp,
+		ConsistencyProof: consistencyProof,
+	}, nil
+}
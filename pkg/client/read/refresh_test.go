@@ -0,0 +1,182 @@
+//
+// Copyright 2025 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package read
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	v1 "github.com/sigstore/protobuf-specs/gen/pb-go/common/v1"
+	pbs "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
+	"github.com/sigstore/rekor-tiles/v2/internal/tessera"
+	pb "github.com/sigstore/rekor-tiles/v2/pkg/generated/protobuf"
+	rekornote "github.com/sigstore/rekor-tiles/v2/pkg/note"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/stretchr/testify/assert"
+	ttessera "github.com/transparency-dev/tessera"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// testLog is a log in local storage served over HTTP as tiles.
+type testLog struct {
+	storage tessera.Storage
+	server  *httptest.Server
+}
+
+func newTestLog(t *testing.T, origin string, signer signature.Signer) *testLog {
+	ctx, cancel := context.WithCancel(context.Background())
+	dir := filepath.Join(t.TempDir(), "log")
+	driver, _, err := tessera.NewDriver(ctx, tessera.DriverConfiguration{StorageDir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ao, err := tessera.NewAppendOptions(ctx, origin, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ao = tessera.WithLifecycleOptions(ao, 10, 10*time.Millisecond, 100*time.Millisecond, tessera.DefaultPushbackMaxOutstanding)
+	storage, shutdown, err := tessera.NewStorage(ctx, origin, driver, ao)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(http.FileServer(http.Dir(dir)))
+	t.Cleanup(func() {
+		server.Close()
+		_ = shutdown(context.Background())
+		cancel()
+	})
+	return &testLog{storage: storage, server: server}
+}
+
+// add adds n hashedrekord entries concurrently, returning them with their
+// canonicalized bodies.
+func (l *testLog) add(t *testing.T, n int) []*pbs.TransparencyLogEntry {
+	entries := make([]*pbs.TransparencyLogEntry, n)
+	var g errgroup.Group
+	for i := range n {
+		g.Go(func() error {
+			digest := sha256.Sum256(fmt.Appendf(nil, "entry %d %s", i, time.Now()))
+			body, err := protojson.Marshal(&pb.Entry{
+				Kind:       "hashedrekord",
+				ApiVersion: "0.0.2",
+				Spec: &pb.Spec{Spec: &pb.Spec_HashedRekordV002{HashedRekordV002: &pb.HashedRekordLogEntryV002{
+					Data: &v1.HashOutput{Algorithm: v1.HashAlgorithm_SHA2_256, Digest: digest[:]},
+				}}},
+			})
+			if err != nil {
+				return err
+			}
+			tle, err := l.storage.Add(context.Background(), ttessera.NewEntry(body))
+			if err != nil {
+				return err
+			}
+			tle.CanonicalizedBody = body
+			entries[i] = tle
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		t.Fatal(err)
+	}
+	return entries
+}
+
+func TestRefreshInclusionProof(t *testing.T) {
+	origin := "rekor-local"
+	sv, _, err := signature.NewDefaultECDSASignerVerifier()
+	if err != nil {
+		t.Fatal(err)
+	}
+	noteVerifier, err := rekornote.NewNoteVerifier(origin, sv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log := newTestLog(t, origin, sv)
+	entries := log.add(t, 3)
+	reader, err := NewReader(log.server.URL, origin, sv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	t.Run("same checkpoint", func(t *testing.T) {
+		// The last entry's inclusion proof is against the latest checkpoint
+		var entry *pbs.TransparencyLogEntry
+		for _, e := range entries {
+			if e.GetLogIndex() == 2 {
+				entry = e
+			}
+		}
+		refreshed, err := RefreshInclusionProof(ctx, reader, entry, noteVerifier)
+		if assert.NoError(t, err) {
+			assert.Equal(t, refreshed.OldCheckpoint.Size, refreshed.Checkpoint.Size)
+			assert.Empty(t, refreshed.ConsistencyProof)
+		}
+	})
+
+	log.add(t, 20)
+	resp, err := http.Get(log.server.URL + "/checkpoint")
+	if err != nil {
+		t.Fatal(err)
+	}
+	latest, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, entry := range entries {
+		t.Run(fmt.Sprintf("entry %d", i), func(t *testing.T) {
+			oldSize := entry.GetInclusionProof().GetTreeSize()
+			refreshed, err := RefreshInclusionProof(ctx, reader, entry, noteVerifier)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, uint64(oldSize), refreshed.OldCheckpoint.Size) //nolint:gosec
+			assert.Equal(t, uint64(23), refreshed.Checkpoint.Size)
+			assert.Equal(t, int64(23), refreshed.Entry.GetInclusionProof().GetTreeSize())
+			assert.Equal(t, oldSize, entry.GetInclusionProof().GetTreeSize(), "original entry should not be modified")
+			assert.NotEmpty(t, refreshed.ConsistencyProof)
+			assert.Equal(t, string(latest), refreshed.Entry.GetInclusionProof().GetCheckpoint().GetEnvelope())
+		})
+	}
+
+	t.Run("missing body", func(t *testing.T) {
+		entry := entries[0]
+		entry.CanonicalizedBody = nil
+		_, err := RefreshInclusionProof(ctx, reader, entry, noteVerifier)
+		assert.ErrorContains(t, err, "canonicalized body")
+	})
+
+	t.Run("checkpoint from another log", func(t *testing.T) {
+		otherSigner, _, err := signature.NewDefaultECDSASignerVerifier()
+		if err != nil {
+			t.Fatal(err)
+		}
+		other := newTestLog(t, origin, otherSigner)
+		entry := other.add(t, 1)[0]
+		_, err = RefreshInclusionProof(ctx, reader, entry, noteVerifier)
+		assert.Error(t, err)
+	})
+}
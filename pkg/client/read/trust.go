@@ -0,0 +1,139 @@
+//
+// Copyright 2025 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package read
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/sigstore/rekor-tiles/v2/pkg/client"
+	"github.com/transparency-dev/formats/log"
+	"github.com/transparency-dev/merkle/proof"
+	"github.com/transparency-dev/merkle/rfc6962"
+	tclient "github.com/transparency-dev/tessera/client"
+	"golang.org/x/mod/sumdb/note"
+)
+
+var (
+	// ErrCheckpointRollback is wrapped by an InconsistentCheckpointError when the latest
+	// checkpoint is for a smaller tree than the trusted checkpoint.
+	ErrCheckpointRollback = errors.New("checkpoint is older than the trusted checkpoint")
+	// ErrSplitView is wrapped by an InconsistentCheckpointError when the latest checkpoint
+	// cannot be proven consistent with the trusted checkpoint, meaning the log has
+	// presented different views of its history.
+	ErrSplitView = errors.New("checkpoint is inconsistent with the trusted checkpoint")
+)
+
+// InconsistentCheckpointError is returned when reading a checkpoint that does not
+// extend the trusted checkpoint. The signed checkpoints are evidence of the log's
+// misbehavior, and can be shared with others to verify.
+type InconsistentCheckpointError struct {
+	// Trusted is the last trusted signed checkpoint
+	Trusted []byte
+	// Latest is the signed checkpoint read from the log
+	Latest []byte
+	// ConsistencyProof is the proof built from the log's tiles that failed to verify,
+	// which is empty unless Latest is for a larger tree than Trusted
+	ConsistencyProof [][]byte
+	// Err is ErrCheckpointRollback or ErrSplitView
+	Err error
+}
+
+func (e *InconsistentCheckpointError) Error() string {
+	return fmt.Sprintf("%v: trusted checkpoint %q, latest checkpoint %q", e.Err, e.Trusted, e.Latest)
+}
+
+func (e *InconsistentCheckpointError) Unwrap() error {
+	return e.Err
+}
+
+// checkpointTracker verifies that each checkpoint read by a client is consistent with
+// the last trusted checkpoint, trusting the first checkpoint read.
+type checkpointTracker struct {
+	mu       sync.Mutex
+	store    client.CheckpointStore
+	origin   string
+	verifier note.Verifier
+}
+
+// newCheckpointTracker returns a tracker for the store, or nil if store is nil.
+func newCheckpointTracker(store client.CheckpointStore, origin string, verifier note.Verifier) *checkpointTracker {
+	if store == nil {
+		return nil
+	}
+	return &checkpointTracker{store: store, origin: origin, verifier: verifier}
+}
+
+// update checks that the latest checkpoint, raw, is consistent with the trusted
+// checkpoint, using tiles read with readTile, and stores it as the trusted checkpoint.
+func (t *checkpointTracker) update(ctx context.Context, readTile tclient.TileFetcherFunc, raw []byte, latest *log.Checkpoint) error {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	trustedRaw, err := t.store.Load(ctx)
+	if err != nil {
+		return fmt.Errorf("loading trusted checkpoint: %w", err)
+	}
+	if trustedRaw != nil {
+		if err := t.check(ctx, readTile, trustedRaw, raw, latest); err != nil {
+			return err
+		}
+	}
+	if err := t.store.Store(ctx, raw); err != nil {
+		return fmt.Errorf("storing trusted checkpoint: %w", err)
+	}
+	return nil
+}
+
+// check returns an InconsistentCheckpointError if the latest checkpoint is not
+// consistent with the trusted checkpoint.
+func (t *checkpointTracker) check(ctx context.Context, readTile tclient.TileFetcherFunc, trustedRaw, raw []byte, latest *log.Checkpoint) error {
+	trusted, _, _, err := log.ParseCheckpoint(trustedRaw, t.origin, t.verifier)
+	if err != nil {
+		return fmt.Errorf("verifying trusted checkpoint: %w", err)
+	}
+	inconsistent := &InconsistentCheckpointError{Trusted: trustedRaw, Latest: raw}
+	switch {
+	case latest.Size < trusted.Size:
+		inconsistent.Err = ErrCheckpointRollback
+		return inconsistent
+	case latest.Size == trusted.Size:
+		if !bytes.Equal(latest.Hash, trusted.Hash) {
+			inconsistent.Err = ErrSplitView
+			return inconsistent
+		}
+		return nil
+	}
+	proofBuilder, err := tclient.NewProofBuilder(ctx, latest.Size, readTile)
+	if err != nil {
+		return fmt.Errorf("creating proof builder: %w", err)
+	}
+	hashes, err := proofBuilder.ConsistencyProof(ctx, trusted.Size, latest.Size)
+	if err != nil {
+		return fmt.Errorf("building consistency proof: %w", err)
+	}
+	if err := proof.VerifyConsistency(rfc6962.DefaultHasher, trusted.Size, latest.Size, hashes, trusted.Hash, latest.Hash); err != nil {
+		inconsistent.ConsistencyProof = hashes
+		inconsistent.Err = ErrSplitView
+		return inconsistent
+	}
+	return nil
+}
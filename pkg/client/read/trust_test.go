@@ -0,0 +1,180 @@
+//
+// Copyright 2025 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package read
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/sigstore/rekor-tiles/v2/pkg/client"
+	rekornote "github.com/sigstore/rekor-tiles/v2/pkg/note"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/stretchr/testify/assert"
+	f_log "github.com/transparency-dev/formats/log"
+)
+
+func TestCheckpointStore(t *testing.T) {
+	origin := "rekor-local"
+	sv, _, err := signature.NewDefaultECDSASignerVerifier()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Two logs signed with the same key present different histories
+	log := newTestLog(t, origin, sv)
+	fork := newTestLog(t, origin, sv)
+	log.add(t, 5)
+	fork.add(t, 5)
+	store := client.NewFileCheckpointStore(filepath.Join(t.TempDir(), "checkpoint"))
+	reader, err := NewReader(log.server.URL, origin, sv, client.WithCheckpointStore(store))
+	if err != nil {
+		t.Fatal(err)
+	}
+	forkReader, err := NewReader(fork.server.URL, origin, sv, client.WithCheckpointStore(store))
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifier, err := rekornote.NewNoteVerifier(origin, sv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	trustedSize := func(t *testing.T) uint64 {
+		trusted, err := store.Load(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cp, _, _, err := f_log.ParseCheckpoint(trusted, origin, verifier)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return cp.Size
+	}
+
+	t.Run("trust on first use", func(t *testing.T) {
+		cp, _, err := reader.ReadCheckpoint(ctx)
+		if assert.NoError(t, err) {
+			assert.Equal(t, cp.Size, trustedSize(t))
+		}
+	})
+
+	t.Run("same size fork", func(t *testing.T) {
+		_, _, err := forkReader.ReadCheckpoint(ctx)
+		var inconsistent *InconsistentCheckpointError
+		if assert.ErrorAs(t, err, &inconsistent) {
+			assert.ErrorIs(t, err, ErrSplitView)
+			assert.NotEmpty(t, inconsistent.Trusted)
+			assert.NotEmpty(t, inconsistent.Latest)
+			assert.Empty(t, inconsistent.ConsistencyProof)
+		}
+		assert.Equal(t, uint64(5), trustedSize(t))
+	})
+
+	t.Run("larger fork", func(t *testing.T) {
+		fork.add(t, 5)
+		_, _, err := forkReader.ReadCheckpoint(ctx)
+		var inconsistent *InconsistentCheckpointError
+		if assert.ErrorAs(t, err, &inconsistent) {
+			assert.ErrorIs(t, err, ErrSplitView)
+			assert.NotEmpty(t, inconsistent.ConsistencyProof)
+		}
+		assert.Equal(t, uint64(5), trustedSize(t))
+	})
+
+	t.Run("consistent", func(t *testing.T) {
+		log.add(t, 10)
+		cp, _, err := reader.ReadCheckpoint(ctx)
+		if assert.NoError(t, err) {
+			assert.Equal(t, uint64(15), cp.Size)
+			assert.Equal(t, uint64(15), trustedSize(t))
+		}
+		// Proofs and entries are read against consistent checkpoints
+		_, err = reader.ConsistencyProof(ctx, 5, 15)
+		assert.NoError(t, err)
+	})
+
+	t.Run("rollback", func(t *testing.T) {
+		_, _, err := forkReader.ReadCheckpoint(ctx)
+		assert.ErrorIs(t, err, ErrCheckpointRollback)
+		assert.False(t, errors.Is(err, ErrSplitView))
+		assert.Equal(t, uint64(15), trustedSize(t))
+	})
+}
+
+func TestCheckpointStoreStaleMirror(t *testing.T) {
+	origin := "rekor-local"
+	sv, _, err := signature.NewDefaultECDSASignerVerifier()
+	if err != nil {
+		t.Fatal(err)
+	}
+	log := newTestLog(t, origin, sv)
+	log.add(t, 5)
+	ctx := context.Background()
+
+	// The stale mirror serves the checkpoint for the first 5 entries after the log grows
+	resp, err := http.Get(log.server.URL + "/checkpoint")
+	if err != nil {
+		t.Fatal(err)
+	}
+	staleCheckpoint, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.add(t, 5)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/checkpoint", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(staleCheckpoint)
+	})
+	mux.Handle("/", proxy(t, log.server.URL))
+	stale, _ := countingServer(t, mux)
+
+	store := client.NewFileCheckpointStore(filepath.Join(t.TempDir(), "checkpoint"))
+	reader, err := NewReader(log.server.URL, origin, sv, client.WithCheckpointStore(store))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := reader.ReadCheckpoint(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("fall back from stale mirror", func(t *testing.T) {
+		reader, err := NewReader(stale.URL, origin, sv, client.WithCheckpointStore(store), client.WithMirrors(log.server.URL))
+		if err != nil {
+			t.Fatal(err)
+		}
+		cp, _, err := reader.ReadCheckpoint(ctx)
+		if assert.NoError(t, err) {
+			assert.Equal(t, uint64(10), cp.Size)
+		}
+	})
+
+	t.Run("every mirror stale", func(t *testing.T) {
+		reader, err := NewReader(stale.URL, origin, sv, client.WithCheckpointStore(store))
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _, err = reader.ReadCheckpoint(ctx)
+		var inconsistent *InconsistentCheckpointError
+		if assert.ErrorAs(t, err, &inconsistent) {
+			assert.ErrorIs(t, err, ErrCheckpointRollback)
+		}
+	})
+}
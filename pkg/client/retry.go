@@ -0,0 +1,290 @@
+//
+// Copyright 2025 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/sigstore/rekor-tiles/v2/pkg/errorinfo"
+	pb "github.com/sigstore/rekor-tiles/v2/pkg/generated/protobuf"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RetryPolicy configures how clients retry failed requests with exponential backoff.
+//
+// Requests that the server did not process, such as those rejected with
+// 429 Too Many Requests or 503 Service Unavailable when the log pushes back,
+// are always retried. Transport errors and gateway errors are only retried for
+// idempotent requests, i.e. everything but adding an entry. If the server sets
+// a Retry-After header or google.rpc.RetryInfo, the client waits for that long
+// instead of the backoff delay.
+type RetryPolicy struct {
+	// InitialBackoff is the delay before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between retries.
+	MaxBackoff time.Duration
+	// Multiplier is the factor the delay grows by after each retry.
+	Multiplier float64
+	// Jitter is the fraction of each delay that is randomized, between 0 and 1.
+	Jitter float64
+	// MaxElapsedTime is the total time after which the client stops retrying.
+	// If zero, only MaxAttempts bounds retries.
+	MaxElapsedTime time.Duration
+	// MaxAttempts is the maximum number of attempts including the first.
+	// If zero, only MaxElapsedTime bounds retries.
+	MaxAttempts int
+}
+
+// DefaultRetryPolicy returns a RetryPolicy suitable for most clients.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     10 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.5,
+		MaxElapsedTime: 1 * time.Minute,
+	}
+}
+
+// WithRetry configures the client to retry failed requests according to the policy.
+// By default, requests are not retried.
+func WithRetry(policy RetryPolicy) Option {
+	return func(c *Config) {
+		c.Retry = &policy
+	}
+}
+
+// maxStatusBodySize limits how much of an error response body is read to check
+// whether the server pushed back.
+const maxStatusBodySize = 64 * 1024
+
+// retrier tracks the attempts made for a single request.
+type retrier struct {
+	policy   RetryPolicy
+	start    time.Time
+	attempts int
+	backoff  time.Duration
+}
+
+func newRetrier(policy RetryPolicy) *retrier {
+	return &retrier{policy: policy, start: time.Now(), attempts: 1, backoff: policy.InitialBackoff}
+}
+
+// delay returns how long to wait before the next attempt, using retryAfter if the
+// server requested a delay, and whether another attempt is allowed by the policy.
+func (r *retrier) delay(retryAfter time.Duration) (time.Duration, bool) {
+	if r.policy.MaxAttempts > 0 && r.attempts >= r.policy.MaxAttempts {
+		return 0, false
+	}
+	if r.policy.MaxAttempts <= 0 && r.policy.MaxElapsedTime <= 0 {
+		return 0, false
+	}
+	d := retryAfter
+	if d <= 0 {
+		d = r.backoff
+		if r.policy.Jitter > 0 {
+			jitter := time.Duration(r.policy.Jitter * float64(d))
+			d = d - jitter + time.Duration(rand.Int64N(int64(jitter)+1)) //nolint:gosec
+		}
+		r.backoff = time.Duration(float64(r.backoff) * max(r.policy.Multiplier, 1))
+		if r.policy.MaxBackoff > 0 && r.backoff > r.policy.MaxBackoff {
+			r.backoff = r.policy.MaxBackoff
+		}
+	}
+	if r.policy.MaxElapsedTime > 0 && time.Since(r.start)+d > r.policy.MaxElapsedTime {
+		return 0, false
+	}
+	return d, true
+}
+
+// wait sleeps before the next attempt, returning false if no further attempt
+// should be made because the policy is exhausted or the context is done.
+func (r *retrier) wait(ctx context.Context, retryAfter time.Duration) bool {
+	d, ok := r.delay(retryAfter)
+	if !ok {
+		return false
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		r.attempts++
+		return true
+	}
+}
+
+type retryRoundTripper struct {
+	http.RoundTripper
+	policy RetryPolicy
+}
+
+// RoundTrip implements http.RoundTripper, retrying requests according to the policy.
+func (rt *retryRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	r := newRetrier(rt.policy)
+	for {
+		resp, err := rt.RoundTripper.RoundTrip(req)
+		if !retryableHTTP(req, resp, err) {
+			return resp, err
+		}
+		var retryAfter time.Duration
+		if resp != nil {
+			retryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+		}
+		if (req.Body != nil && req.GetBody == nil) || !r.wait(req.Context(), retryAfter) {
+			return resp, err
+		}
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+// retryableHTTP returns true if the request failed in a way that is safe to retry.
+// A 503 may be returned for a request that reached the server, so adding an entry
+// is only retried if the server pushed back, which it signals with Retry-After or
+// a google.rpc.ErrorInfo with the pushback reason in the response body.
+func retryableHTTP(req *http.Request, resp *http.Response, err error) bool {
+	idempotent := req.Method == http.MethodGet || req.Method == http.MethodHead
+	if err != nil {
+		return idempotent && req.Context().Err() == nil
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return idempotent || resp.Header.Get("Retry-After") != "" || pushbackHTTP(resp)
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return idempotent
+	default:
+		return false
+	}
+}
+
+// pushbackHTTP returns whether the JSON status in the response body has the
+// pushback reason. The body is restored so that it can still be read by the caller.
+func pushbackHTTP(resp *http.Response) bool {
+	if resp.Body == nil {
+		return false
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxStatusBodySize))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
+	if err != nil {
+		return false
+	}
+	var st struct {
+		Details []struct {
+			Domain string `json:"domain"`
+			Reason string `json:"reason"`
+		} `json:"details"`
+	}
+	if err := json.Unmarshal(body, &st); err != nil {
+		return false
+	}
+	for _, detail := range st.Details {
+		if detail.Domain == errorinfo.LogDomain && detail.Reason == errorinfo.ReasonPushback {
+			return true
+		}
+	}
+	return false
+}
+
+// parseRetryAfter parses a Retry-After header value, either a number of seconds or
+// an HTTP date, returning zero if it is missing or invalid.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return max(time.Until(t), 0)
+	}
+	return 0
+}
+
+// retryInterceptor returns a gRPC interceptor that retries unary calls according to the policy.
+func retryInterceptor(policy RetryPolicy) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		r := newRetrier(policy)
+		for {
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			retryAfter, ok := retryableGRPC(method, err)
+			if !ok || !r.wait(ctx, retryAfter) {
+				return err
+			}
+		}
+	}
+}
+
+// retryableGRPC returns whether a failed call is safe to retry, and the delay
+// requested by the server, if any. An Unavailable status may be returned for a
+// call that reached the server, so adding an entry is only retried if the server
+// pushed back, which it signals with a google.rpc.ErrorInfo with the pushback reason
+// and, if it is configured with a retry delay, google.rpc.RetryInfo.
+func retryableGRPC(method string, err error) (time.Duration, bool) {
+	if err == nil {
+		return 0, false
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return 0, false
+	}
+	var retryAfter time.Duration
+	var pushback bool
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			if d.GetDomain() == errorinfo.LogDomain && d.GetReason() == errorinfo.ReasonPushback {
+				pushback = true
+			}
+		case *errdetails.RetryInfo:
+			retryAfter = d.GetRetryDelay().AsDuration()
+			pushback = true
+		}
+	}
+	idempotent := method != pb.Rekor_CreateEntry_FullMethodName
+	switch st.Code() {
+	case codes.ResourceExhausted:
+		return retryAfter, pushback
+	case codes.Unavailable:
+		return retryAfter, idempotent || pushback
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,315 @@
+//
+// Copyright 2025 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sigstore/rekor-tiles/v2/pkg/errorinfo"
+	pb "github.com/sigstore/rekor-tiles/v2/pkg/generated/protobuf"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestRetrierDelay(t *testing.T) {
+	r := newRetrier(RetryPolicy{InitialBackoff: 1 * time.Second, MaxBackoff: 3 * time.Second, Multiplier: 2, MaxAttempts: 5})
+	for _, want := range []time.Duration{1 * time.Second, 2 * time.Second, 3 * time.Second} {
+		got, ok := r.delay(0)
+		assert.True(t, ok)
+		assert.Equal(t, want, got)
+		r.attempts++
+	}
+	// A delay requested by the server takes precedence
+	got, ok := r.delay(10 * time.Second)
+	assert.True(t, ok)
+	assert.Equal(t, 10*time.Second, got)
+	r.attempts++
+	_, ok = r.delay(0)
+	assert.False(t, ok, "attempts should be exhausted")
+
+	r = newRetrier(RetryPolicy{InitialBackoff: 1 * time.Second, MaxElapsedTime: 2 * time.Second})
+	_, ok = r.delay(0)
+	assert.True(t, ok)
+	_, ok = r.delay(5 * time.Second)
+	assert.False(t, ok, "delay should exceed the maximum elapsed time")
+
+	r = newRetrier(RetryPolicy{InitialBackoff: 1 * time.Second, Jitter: 0.5, MaxAttempts: 2})
+	for range 10 {
+		got, _ = r.delay(0)
+		assert.GreaterOrEqual(t, got, 500*time.Millisecond)
+		assert.LessOrEqual(t, got, 1*time.Second)
+	}
+
+	r = newRetrier(RetryPolicy{InitialBackoff: 1 * time.Second})
+	_, ok = r.delay(0)
+	assert.False(t, ok, "an unbounded policy should not retry")
+}
+
+func TestRetryRoundTripper(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: time.Millisecond, Multiplier: 2, MaxAttempts: 3}
+	tests := []struct {
+		name          string
+		method        string
+		codes         []int
+		retryAfter    string
+		body          string
+		expectCode    int
+		expectAttempt int
+	}{
+		{
+			name:          "pushback then success",
+			method:        http.MethodPost,
+			codes:         []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusCreated},
+			retryAfter:    "0",
+			expectCode:    http.StatusCreated,
+			expectAttempt: 3,
+		},
+		{
+			name:          "too many requests",
+			method:        http.MethodPost,
+			codes:         []int{http.StatusTooManyRequests, http.StatusCreated},
+			retryAfter:    "0",
+			expectCode:    http.StatusCreated,
+			expectAttempt: 2,
+		},
+		{
+			name:          "attempts exhausted",
+			method:        http.MethodPost,
+			codes:         []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusCreated},
+			retryAfter:    "0",
+			expectCode:    http.StatusServiceUnavailable,
+			expectAttempt: 3,
+		},
+		{
+			name:          "unavailable without Retry-After not retried for non-idempotent request",
+			method:        http.MethodPost,
+			codes:         []int{http.StatusServiceUnavailable, http.StatusCreated},
+			expectCode:    http.StatusServiceUnavailable,
+			expectAttempt: 1,
+		},
+		{
+			name:          "pushback without Retry-After retried for non-idempotent request",
+			method:        http.MethodPost,
+			codes:         []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable},
+			body:          `{"code":14,"message":"overloaded","details":[{"@type":"type.googleapis.com/google.rpc.ErrorInfo","reason":"PUSHBACK","domain":"log.rekor.sigstore.dev"}]}`,
+			expectCode:    http.StatusServiceUnavailable,
+			expectAttempt: 3,
+		},
+		{
+			name:          "unavailable without Retry-After retried for idempotent request",
+			method:        http.MethodGet,
+			codes:         []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK},
+			expectCode:    http.StatusOK,
+			expectAttempt: 3,
+		},
+		{
+			name:          "gateway error not retried for non-idempotent request",
+			method:        http.MethodPost,
+			codes:         []int{http.StatusBadGateway, http.StatusCreated},
+			expectCode:    http.StatusBadGateway,
+			expectAttempt: 1,
+		},
+		{
+			name:          "gateway error retried for idempotent request",
+			method:        http.MethodGet,
+			codes:         []int{http.StatusBadGateway, http.StatusGatewayTimeout, http.StatusOK},
+			expectCode:    http.StatusOK,
+			expectAttempt: 3,
+		},
+		{
+			name:          "client error not retried",
+			method:        http.MethodGet,
+			codes:         []int{http.StatusNotFound, http.StatusOK},
+			expectCode:    http.StatusNotFound,
+			expectAttempt: 1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var attempts int
+			server := httptest.NewServer(http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					body, _ := io.ReadAll(r.Body)
+					if r.Method == http.MethodPost {
+						assert.Equal(t, "payload", string(body))
+					}
+					if test.retryAfter != "" {
+						w.Header().Set("Retry-After", test.retryAfter)
+					}
+					w.WriteHeader(test.codes[attempts])
+					w.Write([]byte(test.body))
+					attempts++
+				}))
+			defer server.Close()
+
+			httpClient := &http.Client{Transport: CreateTransport(&Config{Retry: &policy})}
+			var body io.Reader
+			if test.method == http.MethodPost {
+				body = bytes.NewBufferString("payload")
+			}
+			req, err := http.NewRequest(test.method, server.URL, body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := httpClient.Do(req)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+			assert.Equal(t, test.expectCode, resp.StatusCode)
+			assert.Equal(t, test.expectAttempt, attempts)
+			respBody, err := io.ReadAll(resp.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, test.body, string(respBody))
+		})
+	}
+}
+
+func TestRetryRoundTripperContextCanceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+	defer server.Close()
+
+	policy := RetryPolicy{InitialBackoff: time.Hour, MaxAttempts: 2}
+	httpClient := &http.Client{Transport: CreateTransport(&Config{Retry: &policy})}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := httpClient.Do(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	assert.Equal(t, time.Duration(0), parseRetryAfter(""))
+	assert.Equal(t, 3*time.Second, parseRetryAfter("3"))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("-3"))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("soon"))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("Mon, 02 Jan 2006 15:04:05 GMT"))
+	future := parseRetryAfter(time.Now().Add(1 * time.Minute).UTC().Format(http.TimeFormat))
+	assert.Greater(t, future, 50*time.Second)
+}
+
+func TestRetryableGRPC(t *testing.T) {
+	pushbackInfo := &errdetails.ErrorInfo{Domain: errorinfo.LogDomain, Reason: errorinfo.ReasonPushback}
+	pushback, err := status.New(codes.Unavailable, "reached max pushback; retry").
+		WithDetails(pushbackInfo, &errdetails.RetryInfo{RetryDelay: durationpb.New(2 * time.Second)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pushbackNoDelay, err := status.New(codes.Unavailable, "overloaded").WithDetails(pushbackInfo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name             string
+		method           string
+		err              error
+		expectRetry      bool
+		expectRetryAfter time.Duration
+	}{
+		{
+			name:   "success",
+			method: pb.Rekor_GetCheckpoint_FullMethodName,
+		},
+		{
+			name:        "unavailable read",
+			method:      pb.Rekor_GetCheckpoint_FullMethodName,
+			err:         status.Error(codes.Unavailable, "connection refused"),
+			expectRetry: true,
+		},
+		{
+			name:   "unavailable write",
+			method: pb.Rekor_CreateEntry_FullMethodName,
+			err:    status.Error(codes.Unavailable, "connection reset"),
+		},
+		{
+			name:             "pushback write",
+			method:           pb.Rekor_CreateEntry_FullMethodName,
+			err:              pushback.Err(),
+			expectRetry:      true,
+			expectRetryAfter: 2 * time.Second,
+		},
+		{
+			name:        "pushback write without retry delay",
+			method:      pb.Rekor_CreateEntry_FullMethodName,
+			err:         pushbackNoDelay.Err(),
+			expectRetry: true,
+		},
+		{
+			name:   "unavailable write with pushback message only",
+			method: pb.Rekor_CreateEntry_FullMethodName,
+			err:    status.Error(codes.Unavailable, "reached max pushback; retry"),
+		},
+		{
+			name:   "resource exhausted without pushback",
+			method: pb.Rekor_GetTile_FullMethodName,
+			err:    status.Error(codes.ResourceExhausted, "message too large"),
+		},
+		{
+			name:   "invalid argument",
+			method: pb.Rekor_CreateEntry_FullMethodName,
+			err:    status.Error(codes.InvalidArgument, "invalid"),
+		},
+		{
+			name:   "non-status error",
+			method: pb.Rekor_GetCheckpoint_FullMethodName,
+			err:    errors.New("other"),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			retryAfter, ok := retryableGRPC(test.method, test.err)
+			assert.Equal(t, test.expectRetry, ok)
+			assert.Equal(t, test.expectRetryAfter, retryAfter)
+		})
+	}
+}
+
+func TestRetryInterceptor(t *testing.T) {
+	interceptor := retryInterceptor(RetryPolicy{InitialBackoff: time.Millisecond, MaxAttempts: 3})
+	var attempts int
+	invoker := func(_ context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		attempts++
+		if attempts < 3 {
+			return status.Error(codes.Unavailable, "unavailable")
+		}
+		return nil
+	}
+	err := interceptor(context.Background(), pb.Rekor_GetCheckpoint_FullMethodName, nil, nil, nil, invoker)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+
+	attempts = 0
+	err = interceptor(context.Background(), pb.Rekor_CreateEntry_FullMethodName, nil, nil, nil, invoker)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 1, attempts)
+}
@@ -0,0 +1,122 @@
+//
+// Copyright 2025 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sign
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	in_toto "github.com/in-toto/attestation/go/v1"
+	"github.com/secure-systems-lab/go-securesystemslib/dsse"
+	pbdsse "github.com/sigstore/protobuf-specs/gen/pb-go/dsse"
+	pb "github.com/sigstore/rekor-tiles/v2/pkg/generated/protobuf"
+	rekordsse "github.com/sigstore/rekor-tiles/v2/pkg/types/dsse"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature/options"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// InTotoPayloadType is the DSSE payload type of in-toto statements.
+const InTotoPayloadType = "application/vnd.in-toto+json"
+
+// DSSE returns a request to add a dsse entry for an envelope containing the payload,
+// signed with signer. The key details are determined by the signer's public key as
+// for HashedRekord, except that Ed25519 keys are used with pure Ed25519 signatures,
+// so signer must not be an Ed25519ph signer.
+//
+// The request is validated as it would be by the log before it is returned.
+func DSSE(ctx context.Context, signer signature.Signer, payloadType string, payload []byte, opts ...Option) (*pb.DSSERequestV002, error) {
+	return MultiSignedDSSE(ctx, []signature.Signer{signer}, payloadType, payload, opts...)
+}
+
+// MultiSignedDSSE returns a request to add a dsse entry for an envelope containing
+// the payload, signed with each of signers as for DSSE. The request has a verifier
+// for each signer, in the same order as the envelope's signatures.
+func MultiSignedDSSE(ctx context.Context, signers []signature.Signer, payloadType string, payload []byte, opts ...Option) (*pb.DSSERequestV002, error) {
+	if len(signers) == 0 {
+		return nil, fmt.Errorf("no signers")
+	}
+	cfg := newConfig(opts...)
+	pae := dsse.PAE(payloadType, payload)
+	req := &pb.DSSERequestV002{
+		Envelope: &pbdsse.Envelope{
+			Payload:     payload,
+			PayloadType: payloadType,
+		},
+	}
+	for i, signer := range signers {
+		keyDetails, verifier, err := signerVerifier(signer, false, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("signer %d: %w", i, err)
+		}
+		hash, err := hashType(keyDetails)
+		if err != nil {
+			return nil, fmt.Errorf("signer %d: %w", i, err)
+		}
+		sig, err := signer.SignMessage(bytes.NewReader(pae), options.WithContext(ctx), options.WithCryptoSignerOpts(hash))
+		if err != nil {
+			return nil, fmt.Errorf("signer %d: signing envelope: %w", i, err)
+		}
+		req.Envelope.Signatures = append(req.Envelope.Signatures, &pbdsse.Signature{Sig: sig})
+		req.Verifiers = append(req.Verifiers, verifier)
+	}
+	if err := cfg.checkCertificatesUsed(req.Verifiers...); err != nil {
+		return nil, err
+	}
+	if err := validate(req, rekordsse.ToLogEntry); err != nil {
+		return nil, fmt.Errorf("validating request: %w", err)
+	}
+	return req, nil
+}
+
+// InToto returns a request to add a dsse entry for an envelope containing the
+// statement, signed with each of signers as for MultiSignedDSSE. The statement must
+// be a valid in-toto v1 statement.
+func InToto(ctx context.Context, statement *in_toto.Statement, signers []signature.Signer, opts ...Option) (*pb.DSSERequestV002, error) {
+	if err := statement.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid statement: %w", err)
+	}
+	payload, err := protojson.Marshal(statement)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling statement: %w", err)
+	}
+	return MultiSignedDSSE(ctx, signers, InTotoPayloadType, payload, opts...)
+}
+
+// DSSEFromEnvelope returns a request to add a dsse entry for an envelope that has
+// already been signed, such as one produced by another DSSE library, with a verifier
+// for each signature. The request is validated as it would be by the log.
+func DSSEFromEnvelope(env *dsse.Envelope, verifiers ...*pb.Verifier) (*pb.DSSERequestV002, error) {
+	pbEnv, err := rekordsse.ToProto(env)
+	if err != nil {
+		return nil, err
+	}
+	req := &pb.DSSERequestV002{Envelope: pbEnv, Verifiers: verifiers}
+	if err := validate(req, rekordsse.ToLogEntry); err != nil {
+		return nil, fmt.Errorf("validating request: %w", err)
+	}
+	return req, nil
+}
+
+// EnvelopeFromRequest returns the request's envelope in the format used by DSSE
+// libraries, with base64-encoded payload and signatures, or nil if it has no envelope.
+func EnvelopeFromRequest(req *pb.DSSERequestV002) *dsse.Envelope {
+	if req.GetEnvelope() == nil {
+		return nil
+	}
+	return rekordsse.FromProto(req.GetEnvelope())
+}
@@ -0,0 +1,168 @@
+//
+// Copyright 2025 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sign
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"testing"
+
+	in_toto "github.com/in-toto/attestation/go/v1"
+	v1 "github.com/sigstore/protobuf-specs/gen/pb-go/common/v1"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestInToto(t *testing.T) {
+	ctx := context.Background()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p256Key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaSigner, err := signature.LoadRSAPKCS1v15Signer(rsaKey, crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p256Signer, err := signature.LoadECDSASigner(p256Key, crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ed25519Signer, err := signature.LoadED25519Signer(ed25519Key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signers := []signature.Signer{rsaSigner, p256Signer, ed25519Signer}
+
+	predicate, err := structpb.NewStruct(map[string]any{
+		"buildDefinition": map[string]any{"buildType": "https://example.com/build"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	subject := []*in_toto.ResourceDescriptor{{
+		Name:   "artifact",
+		Digest: map[string]string{"sha256": "2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"},
+	}}
+	statement := &in_toto.Statement{
+		Type:          in_toto.StatementTypeUri,
+		Subject:       subject,
+		PredicateType: "https://slsa.dev/provenance/v1",
+		Predicate:     predicate,
+	}
+
+	t.Run("multiple signers", func(t *testing.T) {
+		p256Cert := newCert(t, p256Key)
+		req, err := InToto(ctx, statement, signers, WithCertificate(p256Cert))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, InTotoPayloadType, req.GetEnvelope().GetPayloadType())
+		assert.JSONEq(t, `{
+			"_type": "https://in-toto.io/Statement/v1",
+			"subject": [{"name": "artifact", "digest": {"sha256": "2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"}}],
+			"predicateType": "https://slsa.dev/provenance/v1",
+			"predicate": {"buildDefinition": {"buildType": "https://example.com/build"}}
+		}`, string(req.GetEnvelope().GetPayload()))
+		assert.Len(t, req.GetEnvelope().GetSignatures(), 3)
+		if !assert.Len(t, req.GetVerifiers(), 3) {
+			return
+		}
+		assert.Equal(t, v1.PublicKeyDetails_PKIX_RSA_PKCS1V15_2048_SHA256, req.GetVerifiers()[0].GetKeyDetails())
+		assert.Equal(t, v1.PublicKeyDetails_PKIX_ECDSA_P256_SHA_256, req.GetVerifiers()[1].GetKeyDetails())
+		assert.Equal(t, v1.PublicKeyDetails_PKIX_ED25519, req.GetVerifiers()[2].GetKeyDetails())
+		assert.NotEmpty(t, req.GetVerifiers()[0].GetPublicKey().GetRawBytes())
+		assert.Equal(t, p256Cert.Raw, req.GetVerifiers()[1].GetX509Certificate().GetRawBytes())
+		assert.NotEmpty(t, req.GetVerifiers()[2].GetPublicKey().GetRawBytes())
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		req, err := InToto(ctx, statement, signers)
+		if err != nil {
+			t.Fatal(err)
+		}
+		env := EnvelopeFromRequest(req)
+		assert.Equal(t, base64.StdEncoding.EncodeToString(req.GetEnvelope().GetPayload()), env.Payload)
+		assert.Len(t, env.Signatures, 3)
+		got, err := DSSEFromEnvelope(env, req.GetVerifiers()...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.True(t, proto.Equal(req, got))
+
+		// A signature without a verifier is rejected
+		_, err = DSSEFromEnvelope(env, req.GetVerifiers()[:2]...)
+		assert.Error(t, err)
+
+		env.Payload = base64.StdEncoding.EncodeToString([]byte("tampered"))
+		_, err = DSSEFromEnvelope(env, req.GetVerifiers()...)
+		assert.Error(t, err)
+
+		env.Payload = "not base64"
+		_, err = DSSEFromEnvelope(env, req.GetVerifiers()...)
+		assert.Error(t, err)
+
+		assert.Nil(t, EnvelopeFromRequest(nil))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, test := range []struct {
+			name      string
+			statement *in_toto.Statement
+			signers   []signature.Signer
+			opts      []Option
+			wantErr   error
+			wantMsg   string
+		}{
+			{name: "no signers", statement: statement, wantMsg: "no signers"},
+			{name: "nil statement", statement: nil, signers: signers, wantErr: in_toto.ErrInvalidStatementType},
+			{name: "wrong type", statement: &in_toto.Statement{Type: "https://in-toto.io/Statement/v0.1", Subject: subject, PredicateType: "https://slsa.dev/provenance/v1", Predicate: predicate}, signers: signers, wantErr: in_toto.ErrInvalidStatementType},
+			{name: "no subjects", statement: &in_toto.Statement{Type: in_toto.StatementTypeUri, PredicateType: "https://slsa.dev/provenance/v1", Predicate: predicate}, signers: signers, wantErr: in_toto.ErrSubjectRequired},
+			{name: "no digests", statement: &in_toto.Statement{Type: in_toto.StatementTypeUri, Subject: []*in_toto.ResourceDescriptor{{Name: "artifact"}}, PredicateType: "https://slsa.dev/provenance/v1", Predicate: predicate}, signers: signers, wantErr: in_toto.ErrDigestRequired},
+			{name: "no predicate type", statement: &in_toto.Statement{Type: in_toto.StatementTypeUri, Subject: subject, Predicate: predicate}, signers: signers, wantErr: in_toto.ErrPredicateTypeRequired},
+			{name: "no predicate", statement: &in_toto.Statement{Type: in_toto.StatementTypeUri, Subject: subject, PredicateType: "https://slsa.dev/provenance/v1"}, signers: signers, wantErr: in_toto.ErrPredicateRequired},
+			{name: "unmatched certificate", statement: statement, signers: signers, opts: []Option{WithCertificate(newCert(t, p384Key))}, wantMsg: "certificate does not match"},
+		} {
+			t.Run(test.name, func(t *testing.T) {
+				_, err := InToto(ctx, test.statement, test.signers, test.opts...)
+				if test.wantErr != nil {
+					assert.ErrorIs(t, err, test.wantErr)
+				} else {
+					assert.ErrorContains(t, err, test.wantMsg)
+				}
+			})
+		}
+	})
+}
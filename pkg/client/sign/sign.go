@@ -0,0 +1,163 @@
+//
+// Copyright 2025 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sign
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"slices"
+
+	v1 "github.com/sigstore/protobuf-specs/gen/pb-go/common/v1"
+	"github.com/sigstore/rekor-tiles/v2/internal/algorithmregistry"
+	pb "github.com/sigstore/rekor-tiles/v2/pkg/generated/protobuf"
+	"github.com/sigstore/rekor-tiles/v2/pkg/types/hashedrekord"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature/options"
+)
+
+type config struct {
+	certs []*x509.Certificate
+}
+
+// Option customizes how requests are built.
+type Option func(*config)
+
+// WithCertificate sets the certificate of a signer's key as its verifier in the
+// request, rather than the public key. When signing with multiple signers, it can be
+// given once for each signer.
+func WithCertificate(cert *x509.Certificate) Option {
+	return func(c *config) {
+		c.certs = append(c.certs, cert)
+	}
+}
+
+func newConfig(opts ...Option) *config {
+	cfg := &config{}
+	for _, o := range opts {
+		o(cfg)
+	}
+	return cfg
+}
+
+// HashedRekord returns a request to add a hashedrekord entry for the artifact, signing
+// the artifact's digest with signer. The digest algorithm and key details are
+// determined by the signer's public key:
+//   - RSA keys are used with PKCS#1 v1.5 signatures and SHA-256
+//   - ECDSA keys are used with SHA-256, SHA-384 or SHA-512 for P-256, P-384 and P-521
+//   - Ed25519 keys are used with Ed25519ph signatures and SHA-512, so signer must
+//     be an Ed25519ph signer, such as one from signature.LoadED25519phSigner
+//
+// The request is validated as it would be by the log before it is returned.
+func HashedRekord(ctx context.Context, signer signature.Signer, artifact io.Reader, opts ...Option) (*pb.HashedRekordRequestV002, error) {
+	cfg := newConfig(opts...)
+	keyDetails, verifier, err := signerVerifier(signer, true, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.checkCertificatesUsed(verifier); err != nil {
+		return nil, err
+	}
+	hash, err := hashType(keyDetails)
+	if err != nil {
+		return nil, err
+	}
+	h := hash.New()
+	if _, err := io.Copy(h, artifact); err != nil {
+		return nil, fmt.Errorf("hashing artifact: %w", err)
+	}
+	digest := h.Sum(nil)
+	sig, err := signer.SignMessage(bytes.NewReader(nil), options.WithContext(ctx), options.WithDigest(digest), options.WithCryptoSignerOpts(hash))
+	if err != nil {
+		return nil, fmt.Errorf("signing artifact: %w", err)
+	}
+	req := &pb.HashedRekordRequestV002{
+		Signature: &pb.Signature{Content: sig, Verifier: verifier},
+		Digest:    digest,
+	}
+	if err := validate(req, hashedrekord.ToLogEntry); err != nil {
+		return nil, fmt.Errorf("validating request with key details %v: %w", keyDetails, err)
+	}
+	return req, nil
+}
+
+// signerVerifier returns the key details for the signer's public key, and the
+// verifier to include in a request. Ed25519 keys sign with Ed25519ph if prehash is set.
+func signerVerifier(signer signature.Signer, prehash bool, cfg *config) (v1.PublicKeyDetails, *pb.Verifier, error) {
+	pub, err := signer.PublicKey()
+	if err != nil {
+		return 0, nil, fmt.Errorf("getting public key: %w", err)
+	}
+	var loadOpts []signature.LoadOption
+	if prehash {
+		loadOpts = append(loadOpts, options.WithED25519ph())
+	}
+	keyDetails, err := signature.GetDefaultPublicKeyDetails(pub, loadOpts...)
+	if err != nil {
+		return 0, nil, fmt.Errorf("getting key details: %w", err)
+	}
+	verifier := &pb.Verifier{KeyDetails: keyDetails}
+	for _, cert := range cfg.certs {
+		if cryptoutils.EqualKeys(pub, cert.PublicKey) == nil {
+			verifier.Verifier = &pb.Verifier_X509Certificate{X509Certificate: &v1.X509Certificate{RawBytes: cert.Raw}}
+			return keyDetails, verifier, nil
+		}
+	}
+	der, err := cryptoutils.MarshalPublicKeyToDER(pub)
+	if err != nil {
+		return 0, nil, fmt.Errorf("marshalling public key: %w", err)
+	}
+	verifier.Verifier = &pb.Verifier_PublicKey{PublicKey: &pb.PublicKey{RawBytes: der}}
+	return keyDetails, verifier, nil
+}
+
+// checkCertificatesUsed returns an error if a certificate does not match any
+// signer's key, and so is not one of the verifiers.
+func (c *config) checkCertificatesUsed(verifiers ...*pb.Verifier) error {
+	for _, cert := range c.certs {
+		if !slices.ContainsFunc(verifiers, func(v *pb.Verifier) bool {
+			return bytes.Equal(v.GetX509Certificate().GetRawBytes(), cert.Raw)
+		}) {
+			return fmt.Errorf("certificate does not match the key of any signer: %v", cert.Subject)
+		}
+	}
+	return nil
+}
+
+// validate validates the request with toLogEntry, with the algorithms accepted by a
+// log with the default configuration. Certificate chains are not verified.
+func validate[T, O any](req T, toLogEntry func(T, *signature.AlgorithmRegistryConfig, ...O) (*pb.Entry, error)) error {
+	registry, err := algorithmregistry.AlgorithmRegistry(nil)
+	if err != nil {
+		return fmt.Errorf("getting algorithm registry: %w", err)
+	}
+	if _, err := toLogEntry(req, registry.Config()); err != nil {
+		return err
+	}
+	return nil
+}
+
+func hashType(keyDetails v1.PublicKeyDetails) (crypto.Hash, error) {
+	algDetails, err := signature.GetAlgorithmDetails(keyDetails)
+	if err != nil {
+		return 0, fmt.Errorf("getting algorithm details: %w", err)
+	}
+	return algDetails.GetHashType(), nil
+}
@@ -0,0 +1,188 @@
+//
+// Copyright 2025 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sign
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	v1 "github.com/sigstore/protobuf-specs/gen/pb-go/common/v1"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCert(t *testing.T, priv crypto.Signer) *x509.Certificate {
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "signer"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, priv.Public(), priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+func TestRequests(t *testing.T) {
+	ctx := context.Background()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p256Key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p521Key, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaSigner, err := signature.LoadRSAPKCS1v15Signer(rsaKey, crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaPSSSigner, err := signature.LoadRSAPSSSigner(rsaKey, crypto.SHA256, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The signer's hash does not need to match the key details
+	p256Signer, err := signature.LoadECDSASigner(p256Key, crypto.SHA512)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p384Signer, err := signature.LoadECDSASigner(p384Key, crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p521Signer, err := signature.LoadECDSASigner(p521Key, crypto.SHA512)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ed25519Signer, err := signature.LoadED25519Signer(ed25519Key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ed25519phSigner, err := signature.LoadED25519phSigner(ed25519Key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	artifact := []byte("artifact")
+
+	t.Run("hashedrekord", func(t *testing.T) {
+		for _, test := range []struct {
+			name       string
+			signer     signature.Signer
+			keyDetails v1.PublicKeyDetails
+			wantErr    bool
+		}{
+			{name: "rsa", signer: rsaSigner, keyDetails: v1.PublicKeyDetails_PKIX_RSA_PKCS1V15_2048_SHA256},
+			{name: "ecdsa p256", signer: p256Signer, keyDetails: v1.PublicKeyDetails_PKIX_ECDSA_P256_SHA_256},
+			{name: "ecdsa p384", signer: p384Signer, keyDetails: v1.PublicKeyDetails_PKIX_ECDSA_P384_SHA_384},
+			{name: "ecdsa p521", signer: p521Signer, keyDetails: v1.PublicKeyDetails_PKIX_ECDSA_P521_SHA_512},
+			{name: "ed25519ph", signer: ed25519phSigner, keyDetails: v1.PublicKeyDetails_PKIX_ED25519_PH},
+			{name: "pure ed25519", signer: ed25519Signer, wantErr: true},
+			{name: "rsa pss", signer: rsaPSSSigner, wantErr: true},
+		} {
+			t.Run(test.name, func(t *testing.T) {
+				req, err := HashedRekord(ctx, test.signer, bytes.NewReader(artifact))
+				if test.wantErr {
+					assert.Error(t, err)
+					return
+				}
+				if assert.NoError(t, err) {
+					assert.Equal(t, test.keyDetails, req.GetSignature().GetVerifier().GetKeyDetails())
+					assert.NotEmpty(t, req.GetSignature().GetVerifier().GetPublicKey().GetRawBytes())
+				}
+			})
+		}
+		digest := sha256.Sum256(artifact)
+		req, err := HashedRekord(ctx, p256Signer, bytes.NewReader(artifact))
+		if assert.NoError(t, err) {
+			assert.Equal(t, digest[:], req.GetDigest())
+		}
+	})
+
+	t.Run("dsse", func(t *testing.T) {
+		for _, test := range []struct {
+			name       string
+			signer     signature.Signer
+			keyDetails v1.PublicKeyDetails
+			wantErr    bool
+		}{
+			{name: "rsa", signer: rsaSigner, keyDetails: v1.PublicKeyDetails_PKIX_RSA_PKCS1V15_2048_SHA256},
+			{name: "ecdsa p256", signer: p256Signer, keyDetails: v1.PublicKeyDetails_PKIX_ECDSA_P256_SHA_256},
+			{name: "ecdsa p384", signer: p384Signer, keyDetails: v1.PublicKeyDetails_PKIX_ECDSA_P384_SHA_384},
+			{name: "ecdsa p521", signer: p521Signer, keyDetails: v1.PublicKeyDetails_PKIX_ECDSA_P521_SHA_512},
+			{name: "ed25519", signer: ed25519Signer, keyDetails: v1.PublicKeyDetails_PKIX_ED25519},
+			{name: "ed25519ph", signer: ed25519phSigner, wantErr: true},
+			{name: "rsa pss", signer: rsaPSSSigner, wantErr: true},
+		} {
+			t.Run(test.name, func(t *testing.T) {
+				req, err := DSSE(ctx, test.signer, "application/vnd.in-toto+json", []byte(`{"_type":"https://in-toto.io/Statement/v1"}`))
+				if test.wantErr {
+					assert.Error(t, err)
+					return
+				}
+				if assert.NoError(t, err) {
+					assert.Equal(t, test.keyDetails, req.GetVerifiers()[0].GetKeyDetails())
+					assert.Len(t, req.GetEnvelope().GetSignatures(), 1)
+				}
+			})
+		}
+	})
+
+	t.Run("certificate", func(t *testing.T) {
+		cert := newCert(t, p256Key)
+		req, err := HashedRekord(ctx, p256Signer, bytes.NewReader(artifact), WithCertificate(cert))
+		if assert.NoError(t, err) {
+			assert.Equal(t, cert.Raw, req.GetSignature().GetVerifier().GetX509Certificate().GetRawBytes())
+			assert.Nil(t, req.GetSignature().GetVerifier().GetPublicKey())
+		}
+		dsseReq, err := DSSE(ctx, p256Signer, "text/plain", artifact, WithCertificate(cert))
+		if assert.NoError(t, err) {
+			assert.Equal(t, cert.Raw, dsseReq.GetVerifiers()[0].GetX509Certificate().GetRawBytes())
+		}
+		_, err = HashedRekord(ctx, p384Signer, bytes.NewReader(artifact), WithCertificate(cert))
+		assert.ErrorContains(t, err, "certificate does not match")
+	})
+}
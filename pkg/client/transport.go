@@ -45,3 +45,19 @@ func CreateRoundTripper(inner http.RoundTripper, userAgent string) http.RoundTri
 		userAgent:    userAgent,
 	}
 }
+
+// CreateTransport creates an http.RoundTripper using the TLS configuration,
+// user agent and retry policy from the Config.
+func CreateTransport(cfg *Config) http.RoundTripper {
+	var inner http.RoundTripper = http.DefaultTransport
+	if cfg.TLSConfig != nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = cfg.TLSConfig
+		inner = transport
+	}
+	rt := CreateRoundTripper(inner, cfg.UserAgent)
+	if cfg.Retry != nil {
+		rt = &retryRoundTripper{RoundTripper: rt, policy: *cfg.Retry}
+	}
+	return rt
+}
@@ -0,0 +1,157 @@
+//
+// Copyright 2025 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package write
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sigstore/rekor-tiles/v2/pkg/errorinfo"
+	"github.com/sigstore/rekor-tiles/v2/pkg/types/validation"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ResponseError is returned when the server responds with an error.
+type ResponseError struct {
+	// StatusCode is the HTTP status code, and is zero for gRPC clients.
+	StatusCode int
+	// Code is the gRPC status code.
+	Code codes.Code
+	// Body is the HTTP response body, or the status message for gRPC clients.
+	Body string
+	// RetryAfter is the delay the server asked for before retrying, if any,
+	// for example when the log is overloaded.
+	RetryAfter time.Duration
+	// notIntegrated is set if the status reports that the entry is not yet integrated
+	notIntegrated bool
+}
+
+func (e *ResponseError) Error() string {
+	if e.StatusCode == 0 {
+		return fmt.Sprintf("unexpected response: %v %v", e.Code, e.Body)
+	}
+	return fmt.Sprintf("unexpected response: %v %v", e.StatusCode, e.Body)
+}
+
+// ValidationError is returned when the server rejects an entry as invalid.
+// Reason is a stable code such as validation.ReasonSignatureInvalid, and
+// Field is the path of the request field that caused the rejection.
+type ValidationError struct {
+	*ResponseError
+	Reason      validation.Reason
+	Field       string
+	Description string
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.ResponseError
+}
+
+// AdmissionError is returned when the server denies a valid entry under its admission
+// policy. Rule names the policy rule that denied the entry, and the reason it gives is
+// in the status message.
+type AdmissionError struct {
+	*ResponseError
+	Rule string
+}
+
+func (e *AdmissionError) Unwrap() error {
+	return e.ResponseError
+}
+
+// responseError returns a ValidationError or AdmissionError if the response body is a
+// status with their details, or a ResponseError otherwise.
+func responseError(statusCode int, body []byte) error {
+	respErr := &ResponseError{StatusCode: statusCode, Code: codes.Unknown, Body: string(body)}
+	st := &spb.Status{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(body, st); err != nil {
+		return respErr
+	}
+	respErr.Code = codes.Code(st.GetCode())
+	return withDetails(respErr, st.GetDetails())
+}
+
+// entryProofError returns an error wrapping ErrNotIntegrated if the server reports that
+// the entry is not yet integrated, an error wrapping ErrIndexOutOfRange if the server
+// reports that no entry has been assigned the index, and err otherwise. A NotFound
+// response without the not integrated reason, such as from a wrong URL, is returned as is.
+func entryProofError(index int64, err error) error {
+	var respErr *ResponseError
+	if errors.As(err, &respErr) && respErr.Code == codes.NotFound && respErr.notIntegrated {
+		return fmt.Errorf("index %d: %w", index, ErrNotIntegrated)
+	}
+	if errors.As(err, &respErr) && respErr.Code == codes.OutOfRange {
+		return fmt.Errorf("index %d: %w", index, ErrIndexOutOfRange)
+	}
+	return err
+}
+
+// statusError converts an error returned by a gRPC call to a ValidationError or
+// AdmissionError if the status has their details, or a ResponseError otherwise.
+// Errors caused by the caller's context are returned as is.
+func statusError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() == codes.Canceled || st.Code() == codes.DeadlineExceeded {
+		return err
+	}
+	respErr := &ResponseError{Code: st.Code(), Body: st.Message()}
+	return withDetails(respErr, st.Proto().GetDetails())
+}
+
+// withDetails sets the retry delay and state of respErr from the status details, and returns
+// a ValidationError if the details describe a validation error, or an AdmissionError
+// if they describe an admission policy denial.
+func withDetails(respErr *ResponseError, details []*anypb.Any) error {
+	verr := &ValidationError{ResponseError: respErr}
+	var admissionErr *AdmissionError
+	for _, detail := range details {
+		msg, err := detail.UnmarshalNew()
+		if err != nil {
+			continue
+		}
+		switch m := msg.(type) {
+		case *errdetails.RetryInfo:
+			respErr.RetryAfter = m.GetRetryDelay().AsDuration()
+		case *errdetails.ErrorInfo:
+			switch {
+			case m.GetDomain() == validation.Domain:
+				verr.Reason = validation.Reason(m.GetReason())
+			case m.GetDomain() == errorinfo.AdmissionDomain && m.GetReason() == errorinfo.ReasonAdmissionDenied:
+				admissionErr = &AdmissionError{ResponseError: respErr, Rule: m.GetMetadata()[errorinfo.RuleKey]}
+			case m.GetDomain() == errorinfo.LogDomain && m.GetReason() == errorinfo.ReasonNotIntegrated:
+				respErr.notIntegrated = true
+			}
+		case *errdetails.BadRequest:
+			if violations := m.GetFieldViolations(); len(violations) > 0 {
+				verr.Field = violations[0].GetField()
+				verr.Description = violations[0].GetDescription()
+			}
+		}
+	}
+	if admissionErr != nil {
+		return admissionErr
+	}
+	if verr.Reason == "" {
+		return respErr
+	}
+	return verr
+}
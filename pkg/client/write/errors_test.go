@@ -0,0 +1,146 @@
+//
+// Copyright 2025 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package write
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	pb "github.com/sigstore/rekor-tiles/v2/pkg/generated/protobuf"
+	"github.com/sigstore/rekor-tiles/v2/pkg/types/validation"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+)
+
+func TestResponseError(t *testing.T) {
+	tests := []struct {
+		name             string
+		statusCode       int
+		body             string
+		expectCode       codes.Code
+		expectRetryAfter time.Duration
+		expectValidation *ValidationError
+	}{
+		{
+			name:       "validation error",
+			statusCode: http.StatusBadRequest,
+			expectCode: codes.InvalidArgument,
+			body: `{"code":3,"message":"invalid hashedrekord request","details":[` +
+				`{"@type":"type.googleapis.com/google.rpc.ErrorInfo","reason":"SIGNATURE_INVALID","domain":"rekor.sigstore.dev"},` +
+				`{"@type":"type.googleapis.com/google.rpc.BadRequest","fieldViolations":[{"field":"hashed_rekord_request_v002.signature.content","description":"verifying signature: invalid signature"}]}]}`,
+			expectValidation: &ValidationError{
+				Reason:      validation.ReasonSignatureInvalid,
+				Field:       "hashed_rekord_request_v002.signature.content",
+				Description: "verifying signature: invalid signature",
+			},
+		},
+		{
+			name:       "status without details",
+			statusCode: http.StatusConflict,
+			expectCode: codes.AlreadyExists,
+			body:       `{"code":6,"message":"an equivalent entry already exists in the transparency log with index 0","details":[]}`,
+		},
+		{
+			name:       "error info from another domain",
+			statusCode: http.StatusBadRequest,
+			expectCode: codes.InvalidArgument,
+			body:       `{"code":3,"message":"bad","details":[{"@type":"type.googleapis.com/google.rpc.ErrorInfo","reason":"OTHER","domain":"example.com"}]}`,
+		},
+		{
+			name:             "pushback",
+			statusCode:       http.StatusServiceUnavailable,
+			expectCode:       codes.Unavailable,
+			expectRetryAfter: 1500 * time.Millisecond,
+			body:             `{"code":14,"message":"reached max pushback; retry","details":[{"@type":"type.googleapis.com/google.rpc.RetryInfo","retryDelay":"1.500s"}]}`,
+		},
+		{
+			name:       "non-JSON body",
+			statusCode: http.StatusInternalServerError,
+			expectCode: codes.Unknown,
+			body:       "server died",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := responseError(test.statusCode, []byte(test.body))
+			assert.EqualError(t, err, fmt.Sprintf("unexpected response: %d %s", test.statusCode, test.body))
+			var respErr *ResponseError
+			assert.ErrorAs(t, err, &respErr)
+			assert.Equal(t, test.statusCode, respErr.StatusCode)
+			assert.Equal(t, test.expectCode, respErr.Code)
+			assert.Equal(t, test.expectRetryAfter, respErr.RetryAfter)
+			var verr *ValidationError
+			if test.expectValidation == nil {
+				assert.False(t, errors.As(err, &verr))
+				return
+			}
+			if assert.ErrorAs(t, err, &verr) {
+				assert.Equal(t, test.expectValidation.Reason, verr.Reason)
+				assert.Equal(t, test.expectValidation.Field, verr.Field)
+				assert.Equal(t, test.expectValidation.Description, verr.Description)
+			}
+		})
+	}
+}
+
+func TestAddAdmissionError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"code":7,"message":"entry denied by admission rule \"certificates-only\": public keys are not accepted","details":[` +
+				`{"@type":"type.googleapis.com/google.rpc.ErrorInfo","reason":"ADMISSION_DENIED","domain":"admission.rekor.sigstore.dev","metadata":{"rule":"certificates-only"}}]}`))
+		}))
+	defer server.Close()
+	writer, err := NewWriter(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = writer.Add(context.Background(), &pb.DSSERequestV002{})
+	var admissionErr *AdmissionError
+	if assert.ErrorAs(t, err, &admissionErr) {
+		assert.Equal(t, "certificates-only", admissionErr.Rule)
+		assert.Equal(t, codes.PermissionDenied, admissionErr.Code)
+	}
+	var verr *ValidationError
+	assert.False(t, errors.As(err, &verr))
+}
+
+func TestAddValidationError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":3,"message":"invalid dsse request","details":[` +
+				`{"@type":"type.googleapis.com/google.rpc.ErrorInfo","reason":"UNVERIFIED_DSSE_SIGNATURE","domain":"rekor.sigstore.dev"}]}`))
+		}))
+	defer server.Close()
+	writer, err := NewWriter(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = writer.Add(context.Background(), &pb.DSSERequestV002{})
+	var verr *ValidationError
+	if assert.ErrorAs(t, err, &verr) {
+		assert.Equal(t, validation.ReasonUnverifiedDSSESignature, verr.Reason)
+		assert.Equal(t, http.StatusBadRequest, verr.StatusCode)
+	}
+}
@@ -0,0 +1,174 @@
+//
+// Copyright 2025 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package write
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pbs "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
+	"github.com/sigstore/rekor-tiles/v2/pkg/client"
+	pb "github.com/sigstore/rekor-tiles/v2/pkg/generated/protobuf"
+	"google.golang.org/grpc"
+)
+
+// GRPCClient writes entries to rekor over gRPC. Close must be called to release the connection.
+type GRPCClient interface {
+	Client
+	Close() error
+}
+
+// GRPCAsyncClient writes entries to rekor over gRPC, optionally without waiting for them
+// to be integrated into the log. Close must be called to release the connection.
+type GRPCAsyncClient interface {
+	AsyncClient
+	Close() error
+}
+
+type grpcWriteClient struct {
+	conn           *grpc.ClientConn
+	client         pb.RekorClient
+	timeout        time.Duration
+	returnExisting bool
+	verifier       *entryVerifier
+}
+
+// NewGRPCWriter creates a new writer client for the gRPC service at target, a host and port.
+// Connections use TLS unless the client is configured with client.WithInsecure.
+func NewGRPCWriter(target string, opts ...client.Option) (GRPCClient, error) {
+	return newGRPCWriteClient(target, opts...)
+}
+
+// NewAsyncGRPCWriter creates a new writer client for the gRPC service at target, like
+// NewGRPCWriter, that can also write entries asynchronously.
+func NewAsyncGRPCWriter(target string, opts ...client.Option) (GRPCAsyncClient, error) {
+	return newGRPCWriteClient(target, opts...)
+}
+
+func newGRPCWriteClient(target string, opts ...client.Option) (*grpcWriteClient, error) {
+	cfg := &client.Config{}
+	for _, o := range opts {
+		o(cfg)
+	}
+	verifier, err := cfg.NoteVerifier()
+	if err != nil {
+		return nil, err
+	}
+	conn, err := client.NewGRPCConn(target, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &grpcWriteClient{
+		conn:           conn,
+		client:         pb.NewRekorClient(conn),
+		timeout:        cfg.Timeout,
+		returnExisting: cfg.ReturnExisting,
+		verifier:       newEntryVerifier(verifier),
+	}, nil
+}
+
+// Add uploads a hashedrekord or DSSE log entry and returns the TransparencyLogEntry proving the entry's inclusion in the log.
+// If the client is configured with client.WithReturnExisting, a previously uploaded entry is returned rather than an error.
+// If the server rejects the entry as invalid, the returned error is a *ValidationError, and if
+// the server's admission policy denies the entry, it is an *AdmissionError.
+// If the client is configured with client.WithVerifier, the returned entry is verified and
+// an error wrapping ErrVerification is returned if verification fails.
+func (g *grpcWriteClient) Add(ctx context.Context, entry any) (*pbs.TransparencyLogEntry, error) {
+	return g.add(ctx, entry, false)
+}
+
+// AddAsync uploads a hashedrekord or DSSE log entry and returns as soon as the entry has been assigned a log index.
+// The returned TransparencyLogEntry has no inclusion proof; poll GetEntryProof with its log index to fetch one.
+func (g *grpcWriteClient) AddAsync(ctx context.Context, entry any) (*pbs.TransparencyLogEntry, error) {
+	return g.add(ctx, entry, true)
+}
+
+// GetEntryProof returns the TransparencyLogEntry at the given log index with an inclusion proof.
+// Returns ErrNotIntegrated if the entry is not yet covered by a published checkpoint, or
+// ErrIndexOutOfRange if no entry has been assigned the index.
+// If the client is configured with client.WithVerifier, the entry's index and inclusion proof
+// are verified. The entry is not compared with any request; use GetEntryProofFor to check that
+// the entry was created from the entry passed to AddAsync.
+func (g *grpcWriteClient) GetEntryProof(ctx context.Context, index int64) (*pbs.TransparencyLogEntry, error) {
+	tle, err := g.getEntryProof(ctx, index)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.verifier.verifyProof(tle, index); err != nil {
+		return nil, err
+	}
+	return tle, nil
+}
+
+// GetEntryProofFor is like GetEntryProof, but if the client is configured with
+// client.WithVerifier, it also verifies that the returned entry was created from entry,
+// the hashedrekord or DSSE log entry that was passed to AddAsync, recomputing the leaf
+// hash from the request rather than trusting the returned body.
+func (g *grpcWriteClient) GetEntryProofFor(ctx context.Context, index int64, entry any) (*pbs.TransparencyLogEntry, error) {
+	tle, err := g.getEntryProof(ctx, index)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.verifier.verifyEntryProof(entry, tle, index); err != nil {
+		return nil, err
+	}
+	return tle, nil
+}
+
+func (g *grpcWriteClient) getEntryProof(ctx context.Context, index int64) (*pbs.TransparencyLogEntry, error) {
+	if index < 0 {
+		return nil, fmt.Errorf("invalid index %d", index)
+	}
+	ctx, cancel := g.withTimeout(ctx)
+	defer cancel()
+	tle, err := g.client.GetEntryProof(ctx, &pb.EntryProofRequest{Index: uint64(index)})
+	if err != nil {
+		return nil, entryProofError(index, statusError(err))
+	}
+	return tle, nil
+}
+
+// Close closes the connection to the server.
+func (g *grpcWriteClient) Close() error {
+	return g.conn.Close()
+}
+
+func (g *grpcWriteClient) add(ctx context.Context, entry any, async bool) (*pbs.TransparencyLogEntry, error) {
+	cer, err := createRequest(entry)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	cer.ReturnExisting = g.returnExisting
+	cer.Async = async
+	ctx, cancel := g.withTimeout(ctx)
+	defer cancel()
+	tle, err := g.client.CreateEntry(ctx, cer)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	if err := g.verifier.verifyAdded(entry, tle, !async); err != nil {
+		return nil, err
+	}
+	return tle, nil
+}
+
+func (g *grpcWriteClient) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if g.timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, g.timeout)
+}
@@ -0,0 +1,274 @@
+//
+// Copyright 2025 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package write
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	pbs "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
+	"github.com/sigstore/rekor-tiles/v2/pkg/client"
+	"github.com/sigstore/rekor-tiles/v2/pkg/errorinfo"
+	pb "github.com/sigstore/rekor-tiles/v2/pkg/generated/protobuf"
+	"github.com/sigstore/rekor-tiles/v2/pkg/types/validation"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type fakeRekorServer struct {
+	pb.UnimplementedRekorServer
+	gotReq       *pb.CreateEntryRequest
+	gotUserAgent []string
+	createErr    error
+	createResp   *pbs.TransparencyLogEntry
+	proofResp    *pbs.TransparencyLogEntry
+}
+
+func (f *fakeRekorServer) CreateEntry(ctx context.Context, req *pb.CreateEntryRequest) (*pbs.TransparencyLogEntry, error) {
+	f.gotReq = req
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		f.gotUserAgent = md.Get("user-agent")
+	}
+	if f.createErr != nil {
+		return nil, f.createErr
+	}
+	if f.createResp != nil {
+		return f.createResp, nil
+	}
+	return &pbs.TransparencyLogEntry{LogIndex: 3}, nil
+}
+
+func (f *fakeRekorServer) GetEntryProof(_ context.Context, req *pb.EntryProofRequest) (*pbs.TransparencyLogEntry, error) {
+	if f.proofResp != nil {
+		return f.proofResp, nil
+	}
+	if req.GetIndex() > 5 {
+		return nil, status.Errorf(codes.OutOfRange, "entry %d has not been assigned", req.GetIndex())
+	}
+	if req.GetIndex() == 5 {
+		return nil, status.Errorf(codes.NotFound, "entry %d not found", req.GetIndex())
+	}
+	if req.GetIndex() == 4 {
+		st, err := status.Newf(codes.NotFound, "entry %d is not yet integrated into the log", req.GetIndex()).WithDetails(
+			&errdetails.ErrorInfo{Reason: errorinfo.ReasonNotIntegrated, Domain: errorinfo.LogDomain})
+		if err != nil {
+			return nil, err
+		}
+		return nil, st.Err()
+	}
+	return &pbs.TransparencyLogEntry{LogIndex: int64(req.GetIndex()), InclusionProof: &pbs.InclusionProof{LogIndex: int64(req.GetIndex())}}, nil
+}
+
+func startFakeServer(t *testing.T, fake *fakeRekorServer) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer()
+	pb.RegisterRekorServer(s, fake)
+	go func() { _ = s.Serve(lis) }()
+	t.Cleanup(s.Stop)
+	return lis.Addr().String()
+}
+
+func TestGRPCAdd(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeRekorServer{}
+	target := startFakeServer(t, fake)
+	writer, err := NewAsyncGRPCWriter(target, client.WithInsecure(), client.WithUserAgent("test-agent"), client.WithReturnExisting())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer writer.Close()
+
+	tle, err := writer.Add(ctx, &pb.HashedRekordRequestV002{Digest: []byte("digest")})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), tle.LogIndex)
+	assert.Equal(t, []byte("digest"), fake.gotReq.GetHashedRekordRequestV002().GetDigest())
+	assert.True(t, fake.gotReq.ReturnExisting)
+	assert.False(t, fake.gotReq.Async)
+	if assert.Len(t, fake.gotUserAgent, 1) {
+		assert.Contains(t, fake.gotUserAgent[0], "test-agent")
+	}
+
+	_, err = writer.AddAsync(ctx, &pb.DSSERequestV002{})
+	assert.NoError(t, err)
+	assert.True(t, fake.gotReq.Async)
+
+	_, err = writer.Add(ctx, "intoto entry")
+	assert.ErrorContains(t, err, "unsupported entry type: string")
+}
+
+func TestGRPCAddVerifiesEntry(t *testing.T) {
+	ctx := context.Background()
+	logSigner, _, err := signature.NewDefaultECDSASignerVerifier()
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry := signedHashedRekord(t)
+	body, err := canonicalizedBody(entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fake := &fakeRekorServer{createResp: loggedEntry(t, logSigner, body)}
+	target := startFakeServer(t, fake)
+	writer, err := NewGRPCWriter(target, client.WithInsecure(), client.WithLogVerifier(testOrigin, logSigner))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer writer.Close()
+
+	tle, err := writer.Add(ctx, entry)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), tle.LogIndex)
+
+	fake.createResp.InclusionProof.Hashes = [][]byte{make([]byte, 32)}
+	_, err = writer.Add(ctx, entry)
+	assert.ErrorIs(t, err, ErrVerification)
+}
+
+func TestGRPCGetEntryProofVerifiesEntry(t *testing.T) {
+	ctx := context.Background()
+	logSigner, _, err := signature.NewDefaultECDSASignerVerifier()
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry := signedHashedRekord(t)
+	body, err := canonicalizedBody(entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	target := startFakeServer(t, &fakeRekorServer{proofResp: loggedEntry(t, logSigner, body)})
+	writer, err := NewAsyncGRPCWriter(target, client.WithInsecure(), client.WithLogVerifier(testOrigin, logSigner))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer writer.Close()
+
+	_, err = writer.GetEntryProofFor(ctx, 1, entry)
+	assert.NoError(t, err)
+	// The server answers with a valid proof for the entry at index 1
+	_, err = writer.GetEntryProof(ctx, 0)
+	assert.ErrorIs(t, err, ErrVerification)
+	_, err = writer.GetEntryProofFor(ctx, 1, signedHashedRekord(t))
+	assert.ErrorIs(t, err, ErrVerification)
+}
+
+func TestGRPCAddErrors(t *testing.T) {
+	ctx := context.Background()
+	invalid, err := status.New(codes.InvalidArgument, "invalid hashedrekord request").WithDetails(
+		&errdetails.ErrorInfo{Reason: string(validation.ReasonSignatureInvalid), Domain: validation.Domain},
+		&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: "hashed_rekord_request_v002.signature.content", Description: "verifying signature"}}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pushback, err := status.New(codes.Unavailable, "reached max pushback; retry").WithDetails(
+		&errdetails.ErrorInfo{Reason: errorinfo.ReasonPushback, Domain: errorinfo.LogDomain},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name         string
+		createErr    error
+		expectCode   codes.Code
+		expectReason validation.Reason
+		expectField  string
+	}{
+		{
+			name:         "validation error",
+			createErr:    invalid.Err(),
+			expectCode:   codes.InvalidArgument,
+			expectReason: validation.ReasonSignatureInvalid,
+			expectField:  "hashed_rekord_request_v002.signature.content",
+		},
+		{
+			name:       "duplicate entry",
+			createErr:  status.Error(codes.AlreadyExists, "an equivalent entry already exists in the transparency log with index 0"),
+			expectCode: codes.AlreadyExists,
+		},
+		{
+			name:       "pushback",
+			createErr:  pushback.Err(),
+			expectCode: codes.Unavailable,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			target := startFakeServer(t, &fakeRekorServer{createErr: test.createErr})
+			writer, err := NewGRPCWriter(target, client.WithInsecure())
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer writer.Close()
+
+			_, gotErr := writer.Add(ctx, &pb.HashedRekordRequestV002{})
+			var respErr *ResponseError
+			if assert.ErrorAs(t, gotErr, &respErr) {
+				assert.Equal(t, test.expectCode, respErr.Code)
+				assert.Zero(t, respErr.StatusCode)
+			}
+			var verr *ValidationError
+			if test.expectReason == "" {
+				assert.False(t, errors.As(gotErr, &verr))
+				return
+			}
+			if assert.ErrorAs(t, gotErr, &verr) {
+				assert.Equal(t, test.expectReason, verr.Reason)
+				assert.Equal(t, test.expectField, verr.Field)
+			}
+		})
+	}
+}
+
+func TestGRPCGetEntryProof(t *testing.T) {
+	ctx := context.Background()
+	target := startFakeServer(t, &fakeRekorServer{})
+	asyncWriter, err := NewAsyncGRPCWriter(target, client.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer asyncWriter.Close()
+
+	tle, err := asyncWriter.GetEntryProof(ctx, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), tle.InclusionProof.LogIndex)
+
+	_, err = asyncWriter.GetEntryProof(ctx, 4)
+	assert.ErrorIs(t, err, ErrNotIntegrated)
+
+	_, err = asyncWriter.GetEntryProof(ctx, 5)
+	assert.NotErrorIs(t, err, ErrNotIntegrated)
+	var respErr *ResponseError
+	if assert.ErrorAs(t, err, &respErr) {
+		assert.Equal(t, codes.NotFound, respErr.Code)
+	}
+
+	_, err = asyncWriter.GetEntryProof(ctx, 6)
+	assert.ErrorIs(t, err, ErrIndexOutOfRange)
+
+	_, err = asyncWriter.GetEntryProof(ctx, -1)
+	assert.ErrorContains(t, err, "invalid index -1")
+}
@@ -0,0 +1,118 @@
+//
+// Copyright 2025 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package write
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	pbs "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
+	pb "github.com/sigstore/rekor-tiles/v2/pkg/generated/protobuf"
+	"github.com/sigstore/rekor-tiles/v2/pkg/types/dsse"
+	"github.com/sigstore/rekor-tiles/v2/pkg/types/hashedrekord"
+	"github.com/sigstore/rekor-tiles/v2/pkg/verify"
+	"golang.org/x/mod/sumdb/note"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrVerification is returned when a client configured with client.WithVerifier
+// receives an entry that fails verification.
+var ErrVerification = errors.New("log entry verification failed")
+
+// entryVerifier verifies entries returned by the log. A nil entryVerifier verifies nothing.
+type entryVerifier struct {
+	verifier note.Verifier
+}
+
+func newEntryVerifier(v note.Verifier) *entryVerifier {
+	if v == nil {
+		return nil
+	}
+	return &entryVerifier{verifier: v}
+}
+
+// verifyAdded verifies that tle was created from the submitted entry, and if
+// withProof is set, that its inclusion proof is valid against a signed checkpoint.
+// The leaf hash is recomputed from the request rather than trusting the returned body.
+func (e *entryVerifier) verifyAdded(entry any, tle *pbs.TransparencyLogEntry, withProof bool) error {
+	if e == nil {
+		return nil
+	}
+	body, err := canonicalizedBody(entry)
+	if err != nil {
+		return fmt.Errorf("%w: reconstructing canonicalized body: %w", ErrVerification, err)
+	}
+	if len(tle.GetCanonicalizedBody()) > 0 && !bytes.Equal(tle.GetCanonicalizedBody(), body) {
+		return fmt.Errorf("%w: canonicalized body does not match the submitted entry", ErrVerification)
+	}
+	if !withProof {
+		return nil
+	}
+	expected := proto.Clone(tle).(*pbs.TransparencyLogEntry)
+	expected.CanonicalizedBody = body
+	if err := e.verifyProof(expected, tle.GetLogIndex()); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyProof verifies that tle is the entry at index, and the checkpoint signature
+// and inclusion proof of tle.
+func (e *entryVerifier) verifyProof(tle *pbs.TransparencyLogEntry, index int64) error {
+	if e == nil {
+		return nil
+	}
+	if tle.GetLogIndex() != index {
+		return fmt.Errorf("%w: log index %d does not match requested index %d", ErrVerification, tle.GetLogIndex(), index)
+	}
+	if tle.GetInclusionProof() == nil {
+		return fmt.Errorf("%w: missing inclusion proof", ErrVerification)
+	}
+	if tle.GetInclusionProof().GetLogIndex() != tle.GetLogIndex() {
+		return fmt.Errorf("%w: inclusion proof index %d does not match log index %d", ErrVerification, tle.GetInclusionProof().GetLogIndex(), tle.GetLogIndex())
+	}
+	if err := verify.VerifyLogEntry(tle, e.verifier); err != nil {
+		return fmt.Errorf("%w: %w", ErrVerification, err)
+	}
+	return nil
+}
+
+// verifyEntryProof verifies that tle is the entry at index created from the submitted
+// entry, with a valid inclusion proof for the entry's canonicalized body.
+func (e *entryVerifier) verifyEntryProof(entry any, tle *pbs.TransparencyLogEntry, index int64) error {
+	if e == nil {
+		return nil
+	}
+	if tle.GetLogIndex() != index {
+		return fmt.Errorf("%w: log index %d does not match requested index %d", ErrVerification, tle.GetLogIndex(), index)
+	}
+	return e.verifyAdded(entry, tle, true)
+}
+
+// canonicalizedBody returns the canonicalized log entry the server creates for a request.
+func canonicalizedBody(entry any) ([]byte, error) {
+	switch e := entry.(type) {
+	case *pb.HashedRekordRequestV002:
+		return verify.HashedRekordCanonicalizedBody(e.GetDigest(), e.GetSignature(),
+			&pbs.KindVersion{Kind: hashedrekord.Kind, Version: hashedrekord.APIVersion})
+	case *pb.DSSERequestV002:
+		return verify.DSSECanonicalizedBody(e.GetEnvelope(), e.GetVerifiers(),
+			&pbs.KindVersion{Kind: dsse.Kind, Version: dsse.APIVersion})
+	default:
+		return nil, fmt.Errorf("unsupported entry type: %T", entry)
+	}
+}
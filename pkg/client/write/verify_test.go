@@ -0,0 +1,300 @@
+//
+// Copyright 2025 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package write
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v1 "github.com/sigstore/protobuf-specs/gen/pb-go/common/v1"
+	pbs "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
+	"github.com/sigstore/rekor-tiles/v2/pkg/client"
+	pb "github.com/sigstore/rekor-tiles/v2/pkg/generated/protobuf"
+	rekornote "github.com/sigstore/rekor-tiles/v2/pkg/note"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature/options"
+	"github.com/stretchr/testify/assert"
+	f_log "github.com/transparency-dev/formats/log"
+	"github.com/transparency-dev/merkle/rfc6962"
+	"golang.org/x/mod/sumdb/note"
+	"google.golang.org/protobuf/proto"
+)
+
+const testOrigin = "rekor-local"
+
+// signedHashedRekord returns a hashedrekord request signed by a new key.
+func signedHashedRekord(t *testing.T) *pb.HashedRekordRequestV002 {
+	t.Helper()
+	sv, _, err := signature.NewDefaultECDSASignerVerifier()
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256([]byte("artifact"))
+	sig, err := sv.SignMessage(bytes.NewReader(digest[:]), options.WithDigest(digest[:]), options.WithCryptoSignerOpts(crypto.SHA256))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubKey, err := sv.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(pubKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &pb.HashedRekordRequestV002{
+		Digest: digest[:],
+		Signature: &pb.Signature{
+			Content: sig,
+			Verifier: &pb.Verifier{
+				Verifier:   &pb.Verifier_PublicKey{PublicKey: &pb.PublicKey{RawBytes: der}},
+				KeyDetails: v1.PublicKeyDetails_PKIX_ECDSA_P256_SHA_256,
+			},
+		},
+	}
+}
+
+// loggedEntry returns a TransparencyLogEntry for body at index 1 of a two entry log,
+// with a checkpoint signed by signer.
+func loggedEntry(t *testing.T, signer signature.Signer, body []byte) *pbs.TransparencyLogEntry {
+	t.Helper()
+	sibling := rfc6962.DefaultHasher.HashLeaf([]byte("first entry"))
+	root := rfc6962.DefaultHasher.HashChildren(sibling, rfc6962.DefaultHasher.HashLeaf(body))
+	noteSigner, err := rekornote.NewNoteSigner(context.Background(), testOrigin, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cp := f_log.Checkpoint{Origin: testOrigin, Size: 2, Hash: root}.Marshal()
+	n, err := note.Sign(&note.Note{Text: string(cp)}, noteSigner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &pbs.TransparencyLogEntry{
+		LogIndex:          1,
+		CanonicalizedBody: body,
+		InclusionProof: &pbs.InclusionProof{
+			LogIndex:   1,
+			TreeSize:   2,
+			RootHash:   root,
+			Hashes:     [][]byte{sibling},
+			Checkpoint: &pbs.Checkpoint{Envelope: string(n)},
+		},
+	}
+}
+
+func TestAddVerifiesEntry(t *testing.T) {
+	logSigner, _, err := signature.NewDefaultECDSASignerVerifier()
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherSigner, _, err := signature.NewDefaultECDSASignerVerifier()
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry := signedHashedRekord(t)
+	body, err := canonicalizedBody(entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherBody, err := canonicalizedBody(signedHashedRekord(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		tle       func() *pbs.TransparencyLogEntry
+		expectErr string
+	}{
+		{
+			name: "valid entry",
+			tle:  func() *pbs.TransparencyLogEntry { return loggedEntry(t, logSigner, body) },
+		},
+		{
+			name: "valid entry without canonicalized body",
+			tle: func() *pbs.TransparencyLogEntry {
+				tle := loggedEntry(t, logSigner, body)
+				tle.CanonicalizedBody = nil
+				return tle
+			},
+		},
+		{
+			name: "body does not match request",
+			tle: func() *pbs.TransparencyLogEntry {
+				tle := loggedEntry(t, logSigner, body)
+				tle.CanonicalizedBody = otherBody
+				return tle
+			},
+			expectErr: "canonicalized body does not match the submitted entry",
+		},
+		{
+			name:      "proof for another entry",
+			tle:       func() *pbs.TransparencyLogEntry { return loggedEntry(t, logSigner, otherBody) },
+			expectErr: "canonicalized body does not match the submitted entry",
+		},
+		{
+			name: "proof for another entry without canonicalized body",
+			tle: func() *pbs.TransparencyLogEntry {
+				tle := loggedEntry(t, logSigner, otherBody)
+				tle.CanonicalizedBody = nil
+				return tle
+			},
+			expectErr: "verifying inclusion",
+		},
+		{
+			name:      "checkpoint signed by another key",
+			tle:       func() *pbs.TransparencyLogEntry { return loggedEntry(t, otherSigner, body) },
+			expectErr: "unverified checkpoint signature",
+		},
+		{
+			name: "invalid inclusion proof",
+			tle: func() *pbs.TransparencyLogEntry {
+				tle := loggedEntry(t, logSigner, body)
+				tle.InclusionProof.Hashes = [][]byte{make([]byte, 32)}
+				return tle
+			},
+			expectErr: "verifying inclusion",
+		},
+		{
+			name: "mismatched proof index",
+			tle: func() *pbs.TransparencyLogEntry {
+				tle := loggedEntry(t, logSigner, body)
+				tle.LogIndex = 0
+				return tle
+			},
+			expectErr: "inclusion proof index 1 does not match log index 0",
+		},
+		{
+			name: "missing inclusion proof",
+			tle: func() *pbs.TransparencyLogEntry {
+				tle := loggedEntry(t, logSigner, body)
+				tle.InclusionProof = nil
+				return tle
+			},
+			expectErr: "missing inclusion proof",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(
+				func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusCreated)
+					w.Write(marshalJSONOrDie(t, test.tle()))
+				}))
+			defer server.Close()
+
+			writer, err := NewWriter(server.URL, client.WithLogVerifier(testOrigin, logSigner))
+			if err != nil {
+				t.Fatal(err)
+			}
+			tle, err := writer.Add(context.Background(), entry)
+			if test.expectErr == "" {
+				assert.NoError(t, err)
+				assert.NotNil(t, tle)
+				return
+			}
+			assert.ErrorIs(t, err, ErrVerification)
+			assert.ErrorContains(t, err, test.expectErr)
+			assert.Nil(t, tle)
+
+			// Without a verifier, the entry is returned as is
+			writer, err = NewWriter(server.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = writer.Add(context.Background(), entry)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestAddAsyncVerifiesEntry(t *testing.T) {
+	logSigner, _, err := signature.NewDefaultECDSASignerVerifier()
+	if err != nil {
+		t.Fatal(err)
+	}
+	noteVerifier, err := rekornote.NewNoteVerifier(testOrigin, logSigner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry := signedHashedRekord(t)
+	body, err := canonicalizedBody(entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tle := loggedEntry(t, logSigner, body)
+	var respTLE *pbs.TransparencyLogEntry
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodGet {
+				w.WriteHeader(http.StatusOK)
+			} else {
+				w.WriteHeader(http.StatusAccepted)
+			}
+			w.Write(marshalJSONOrDie(t, respTLE))
+		}))
+	defer server.Close()
+	writer, err := NewAsyncWriter(server.URL, client.WithVerifier(noteVerifier))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Asynchronous responses have no proof, but the body is still checked
+	respTLE = &pbs.TransparencyLogEntry{LogIndex: 1, CanonicalizedBody: body}
+	_, err = writer.AddAsync(context.Background(), entry)
+	assert.NoError(t, err)
+	respTLE.CanonicalizedBody = []byte("{}")
+	_, err = writer.AddAsync(context.Background(), entry)
+	assert.ErrorIs(t, err, ErrVerification)
+
+	respTLE = tle
+	_, err = writer.GetEntryProof(context.Background(), 1)
+	assert.NoError(t, err)
+	_, err = writer.GetEntryProofFor(context.Background(), 1, entry)
+	assert.NoError(t, err)
+	// A valid proof for another index is rejected
+	_, err = writer.GetEntryProof(context.Background(), 0)
+	assert.ErrorIs(t, err, ErrVerification)
+	_, err = writer.GetEntryProofFor(context.Background(), 0, entry)
+	assert.ErrorIs(t, err, ErrVerification)
+	// A valid proof for another entry is rejected
+	_, err = writer.GetEntryProofFor(context.Background(), 1, signedHashedRekord(t))
+	assert.ErrorIs(t, err, ErrVerification)
+	respTLE = proto.Clone(tle).(*pbs.TransparencyLogEntry)
+	respTLE.InclusionProof.Hashes = nil
+	_, err = writer.GetEntryProof(context.Background(), 1)
+	assert.ErrorIs(t, err, ErrVerification)
+}
+
+func TestCanonicalizedBody(t *testing.T) {
+	entry := signedHashedRekord(t)
+	body, err := canonicalizedBody(entry)
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), `"kind":"hashedrekord"`)
+
+	entry.Signature.Content = []byte("invalid")
+	_, err = canonicalizedBody(entry)
+	assert.Error(t, err)
+
+	_, err = canonicalizedBody("intoto entry")
+	assert.ErrorContains(t, err, "unsupported entry type: string")
+}
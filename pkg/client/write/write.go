@@ -18,6 +18,7 @@ package write
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -31,21 +32,50 @@ import (
 )
 
 const (
-	addPath = "/api/v2/log/entries"
+	addPath   = "/api/v2/log/entries"
+	proofPath = "/api/v2/log/entries/%d/proof"
 )
 
+// ErrNotIntegrated is returned by GetEntryProof when the entry has not yet been
+// integrated into a published checkpoint. Callers should retry later.
+var ErrNotIntegrated = errors.New("entry not yet integrated into the log")
+
+// ErrIndexOutOfRange is returned by GetEntryProof when no entry has been assigned
+// the index. Unlike ErrNotIntegrated, retrying will not succeed until the log grows.
+var ErrIndexOutOfRange = errors.New("index not assigned to any entry")
+
 // Client writes entries to rekor.
 type Client interface {
 	Add(context.Context, any) (*pbs.TransparencyLogEntry, error)
 }
 
+// AsyncClient writes entries to rekor without waiting for them to be integrated into
+// the log. Use NewAsyncWriter or NewAsyncGRPCWriter to create one.
+type AsyncClient interface {
+	Client
+	AddAsync(context.Context, any) (*pbs.TransparencyLogEntry, error)
+	GetEntryProof(context.Context, int64) (*pbs.TransparencyLogEntry, error)
+	GetEntryProofFor(context.Context, int64, any) (*pbs.TransparencyLogEntry, error)
+}
+
 type writeClient struct {
-	baseURL *url.URL
-	client  *http.Client
+	baseURL        *url.URL
+	client         *http.Client
+	returnExisting bool
+	verifier       *entryVerifier
 }
 
 // NewWriter creates a new writer client.
 func NewWriter(writeURL string, opts ...client.Option) (Client, error) {
+	return newWriteClient(writeURL, opts...)
+}
+
+// NewAsyncWriter creates a new writer client that can also write entries asynchronously.
+func NewAsyncWriter(writeURL string, opts ...client.Option) (AsyncClient, error) {
+	return newWriteClient(writeURL, opts...)
+}
+
+func newWriteClient(writeURL string, opts ...client.Option) (*writeClient, error) {
 	cfg := &client.Config{}
 	for _, o := range opts {
 		o(cfg)
@@ -54,22 +84,107 @@ func NewWriter(writeURL string, opts ...client.Option) (Client, error) {
 	if err != nil {
 		return nil, fmt.Errorf("parsing url %s: %w", writeURL, err)
 	}
+	verifier, err := cfg.NoteVerifier()
+	if err != nil {
+		return nil, err
+	}
 	httpClient := &http.Client{
-		Transport: client.CreateRoundTripper(http.DefaultTransport, cfg.UserAgent),
+		Transport: client.CreateTransport(cfg),
 		Timeout:   cfg.Timeout,
 	}
 	return &writeClient{
-		baseURL: baseURL,
-		client:  httpClient,
+		baseURL:        baseURL,
+		client:         httpClient,
+		returnExisting: cfg.ReturnExisting,
+		verifier:       newEntryVerifier(verifier),
 	}, nil
 }
 
 // Add uploads a hashedrekord or DSSE log entry and returns the TransparencyLogEntry proving the entry's inclusion in the log.
+// If the client is configured with client.WithReturnExisting, a previously uploaded entry is returned rather than an error.
+// If the server rejects the entry as invalid, the returned error is a *ValidationError, and if
+// the server's admission policy denies the entry, it is an *AdmissionError.
+// If the client is configured with client.WithVerifier, the returned entry is verified and
+// an error wrapping ErrVerification is returned if verification fails.
 func (w *writeClient) Add(ctx context.Context, entry any) (*pbs.TransparencyLogEntry, error) {
+	return w.add(ctx, entry, false, http.StatusCreated)
+}
+
+// AddAsync uploads a hashedrekord or DSSE log entry and returns as soon as the entry has been assigned a log index.
+// The returned TransparencyLogEntry has no inclusion proof; poll GetEntryProof with its log index to fetch one.
+func (w *writeClient) AddAsync(ctx context.Context, entry any) (*pbs.TransparencyLogEntry, error) {
+	return w.add(ctx, entry, true, http.StatusAccepted)
+}
+
+// GetEntryProof returns the TransparencyLogEntry at the given log index with an inclusion proof.
+// Returns ErrNotIntegrated if the entry is not yet covered by a published checkpoint, or
+// ErrIndexOutOfRange if no entry has been assigned the index.
+// If the client is configured with client.WithVerifier, the entry's index and inclusion proof
+// are verified. The entry is not compared with any request; use GetEntryProofFor to check that
+// the entry was created from the entry passed to AddAsync.
+func (w *writeClient) GetEntryProof(ctx context.Context, index int64) (*pbs.TransparencyLogEntry, error) {
+	tle, err := w.getEntryProof(ctx, index)
+	if err != nil {
+		return nil, err
+	}
+	if err := w.verifier.verifyProof(tle, index); err != nil {
+		return nil, err
+	}
+	return tle, nil
+}
+
+// GetEntryProofFor is like GetEntryProof, but if the client is configured with
+// client.WithVerifier, it also verifies that the returned entry was created from entry,
+// the hashedrekord or DSSE log entry that was passed to AddAsync, recomputing the leaf
+// hash from the request rather than trusting the returned body.
+func (w *writeClient) GetEntryProofFor(ctx context.Context, index int64, entry any) (*pbs.TransparencyLogEntry, error) {
+	tle, err := w.getEntryProof(ctx, index)
+	if err != nil {
+		return nil, err
+	}
+	if err := w.verifier.verifyEntryProof(entry, tle, index); err != nil {
+		return nil, err
+	}
+	return tle, nil
+}
+
+func (w *writeClient) getEntryProof(ctx context.Context, index int64) (*pbs.TransparencyLogEntry, error) {
+	if index < 0 {
+		return nil, fmt.Errorf("invalid index %d", index)
+	}
+	endpoint := *w.baseURL
+	endpoint.Path = path.Join(endpoint.Path, fmt.Sprintf(proofPath, index))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("getting response: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, entryProofError(index, responseError(resp.StatusCode, body))
+	}
+	tle := pbs.TransparencyLogEntry{}
+	err = protojson.Unmarshal(body, &tle)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshaling response body: %w", err)
+	}
+	return &tle, nil
+}
+
+func (w *writeClient) add(ctx context.Context, entry any, async bool, expectedCode int) (*pbs.TransparencyLogEntry, error) {
 	cer, err := createRequest(entry)
 	if err != nil {
 		return nil, fmt.Errorf("creating request: %w", err)
 	}
+	cer.ReturnExisting = w.returnExisting
+	cer.Async = async
 	endpoint := *w.baseURL
 	endpoint.Path = path.Join(endpoint.Path, addPath)
 
@@ -91,14 +206,18 @@ func (w *writeClient) Add(ctx context.Context, entry any) (*pbs.TransparencyLogE
 	if err != nil {
 		return nil, fmt.Errorf("reading response: %w", err)
 	}
-	if resp.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("unexpected response: %v %v", resp.StatusCode, string(body))
+	// The server responds with 200 rather than 201 or 202 when returning an existing entry
+	if resp.StatusCode != expectedCode && (!w.returnExisting || resp.StatusCode != http.StatusOK) {
+		return nil, responseError(resp.StatusCode, body)
 	}
 	tle := pbs.TransparencyLogEntry{}
 	err = protojson.Unmarshal(body, &tle)
 	if err != nil {
 		return nil, fmt.Errorf("unmarshaling response body: %w", err)
 	}
+	if err := w.verifier.verifyAdded(entry, &tle, !async); err != nil {
+		return nil, err
+	}
 	return &tle, nil
 }
 
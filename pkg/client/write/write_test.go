@@ -20,6 +20,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -33,6 +34,7 @@ import (
 	"github.com/sigstore/rekor-tiles/v2/pkg/client"
 	pb "github.com/sigstore/rekor-tiles/v2/pkg/generated/protobuf"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protojson"
 )
 
 func TestNewWriter(t *testing.T) {
@@ -93,6 +95,140 @@ func TestNewWriter(t *testing.T) {
 	}
 }
 
+func TestAddReturnExisting(t *testing.T) {
+	entry := &pb.HashedRekordRequestV002{Digest: []byte("digest")}
+	respBody := marshalJSONOrDie(t, pbs.TransparencyLogEntry{LogIndex: 1})
+	var gotReq pb.CreateEntryRequest
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			if err := protojson.Unmarshal(body, &gotReq); err != nil {
+				t.Fatal(err)
+			}
+			w.WriteHeader(http.StatusOK)
+			w.Write(respBody)
+		}))
+	defer server.Close()
+
+	writer, err := NewWriter(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = writer.Add(context.Background(), entry)
+	assert.ErrorContains(t, err, "unexpected response: 200")
+	assert.False(t, gotReq.ReturnExisting)
+
+	writer, err = NewWriter(server.URL, client.WithReturnExisting())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tle, err := writer.Add(context.Background(), entry)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), tle.LogIndex)
+	assert.True(t, gotReq.ReturnExisting)
+}
+
+func TestAddAsync(t *testing.T) {
+	entry := &pb.HashedRekordRequestV002{Digest: []byte("digest")}
+	var gotReq pb.CreateEntryRequest
+	server := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			if err := protojson.Unmarshal(body, &gotReq); err != nil {
+				t.Fatal(err)
+			}
+			w.WriteHeader(http.StatusAccepted)
+			w.Write(marshalJSONOrDie(t, pbs.TransparencyLogEntry{LogIndex: 7}))
+		}))
+	defer server.Close()
+
+	writer, err := NewAsyncWriter(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tle, err := writer.AddAsync(context.Background(), entry)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), tle.LogIndex)
+	assert.Nil(t, tle.InclusionProof)
+	assert.True(t, gotReq.Async)
+
+	// A synchronous request must not accept a 202
+	_, err = writer.Add(context.Background(), entry)
+	assert.ErrorContains(t, err, "unexpected response: 202")
+	assert.False(t, gotReq.Async)
+}
+
+func TestGetEntryProof(t *testing.T) {
+	tests := []struct {
+		name      string
+		respBody  []byte
+		respCode  int
+		expectErr error
+	}{
+		{
+			name:     "integrated entry",
+			respBody: marshalJSONOrDie(t, pbs.TransparencyLogEntry{LogIndex: 7, InclusionProof: &pbs.InclusionProof{LogIndex: 7, TreeSize: 8}}),
+			respCode: http.StatusOK,
+		},
+		{
+			name:      "not yet integrated",
+			respBody:  []byte(`{"code":5,"message":"entry 7 is not yet integrated into the log","details":[{"@type":"type.googleapis.com/google.rpc.ErrorInfo","reason":"NOT_INTEGRATED","domain":"log.rekor.sigstore.dev"}]}`),
+			respCode:  http.StatusNotFound,
+			expectErr: ErrNotIntegrated,
+		},
+		{
+			name:      "route not found",
+			respBody:  []byte("404 page not found"),
+			respCode:  http.StatusNotFound,
+			expectErr: fmt.Errorf("unexpected response: 404 404 page not found"),
+		},
+		{
+			name:      "index not assigned",
+			respBody:  []byte(`{"code":11,"message":"entry 7 has not been assigned"}`),
+			respCode:  http.StatusBadRequest,
+			expectErr: ErrIndexOutOfRange,
+		},
+		{
+			name:      "server error",
+			respBody:  []byte("server died"),
+			respCode:  http.StatusInternalServerError,
+			expectErr: fmt.Errorf("unexpected response: 500 server died"),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var gotPath string
+			server := httptest.NewServer(http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					gotPath = r.URL.Path
+					w.WriteHeader(test.respCode)
+					w.Write(test.respBody)
+				}))
+			defer server.Close()
+			writer, err := NewAsyncWriter(server.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tle, gotErr := writer.GetEntryProof(context.Background(), 7)
+			assert.Equal(t, "/api/v2/log/entries/7/proof", gotPath)
+			if test.expectErr != nil {
+				assert.ErrorContains(t, gotErr, test.expectErr.Error())
+				return
+			}
+			assert.NoError(t, gotErr)
+			assert.Equal(t, int64(7), tle.InclusionProof.LogIndex)
+		})
+	}
+
+	writer, err := NewAsyncWriter("http://localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = writer.GetEntryProof(context.Background(), -1)
+	assert.ErrorContains(t, err, "invalid index -1")
+}
+
 func TestAdd(t *testing.T) {
 	tests := []struct {
 		name      string
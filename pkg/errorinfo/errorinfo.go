@@ -0,0 +1,37 @@
+//
+// Copyright 2025 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package errorinfo defines the google.rpc.ErrorInfo domains and reasons that Rekor
+// attaches to error statuses other than request validation errors, which are defined
+// by the validation package.
+package errorinfo
+
+const (
+	// AdmissionDomain is the ErrorInfo domain for entries denied by the log's admission policy.
+	AdmissionDomain = "admission.rekor.sigstore.dev"
+	// ReasonAdmissionDenied is returned when a valid entry is denied by the log's admission policy.
+	ReasonAdmissionDenied = "ADMISSION_DENIED"
+	// RuleKey is the ErrorInfo metadata key naming the admission rule that denied an entry.
+	RuleKey = "rule"
+
+	// LogDomain is the ErrorInfo domain for errors describing the state of the log.
+	LogDomain = "log.rekor.sigstore.dev"
+	// ReasonNotIntegrated is returned by the entry proof endpoint when the entry has been
+	// assigned an index but is not yet integrated into a published checkpoint.
+	ReasonNotIntegrated = "NOT_INTEGRATED"
+	// ReasonPushback is returned when the log rejects an entry without adding it because
+	// it is overloaded. The entry can be resubmitted.
+	ReasonPushback = "PUSHBACK"
+)
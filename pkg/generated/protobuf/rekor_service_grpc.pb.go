@@ -37,6 +37,7 @@ const _ = grpc.SupportPackageIsVersion9
 
 const (
 	Rekor_CreateEntry_FullMethodName    = "/dev.sigstore.rekor.v2.Rekor/CreateEntry"
+	Rekor_GetEntryProof_FullMethodName  = "/dev.sigstore.rekor.v2.Rekor/GetEntryProof"
 	Rekor_GetTile_FullMethodName        = "/dev.sigstore.rekor.v2.Rekor/GetTile"
 	Rekor_GetEntryBundle_FullMethodName = "/dev.sigstore.rekor.v2.Rekor/GetEntryBundle"
 	Rekor_GetCheckpoint_FullMethodName  = "/dev.sigstore.rekor.v2.Rekor/GetCheckpoint"
@@ -51,6 +52,8 @@ const (
 type RekorClient interface {
 	// Create an entry in the log
 	CreateEntry(ctx context.Context, in *CreateEntryRequest, opts ...grpc.CallOption) (*v1.TransparencyLogEntry, error)
+	// Get an entry with an inclusion proof, once the entry has been integrated into the log
+	GetEntryProof(ctx context.Context, in *EntryProofRequest, opts ...grpc.CallOption) (*v1.TransparencyLogEntry, error)
 	// Get a tile from the log
 	GetTile(ctx context.Context, in *TileRequest, opts ...grpc.CallOption) (*httpbody.HttpBody, error)
 	// Get an entry bundle from the log
@@ -77,6 +80,16 @@ func (c *rekorClient) CreateEntry(ctx context.Context, in *CreateEntryRequest, o
 	return out, nil
 }
 
+func (c *rekorClient) GetEntryProof(ctx context.Context, in *EntryProofRequest, opts ...grpc.CallOption) (*v1.TransparencyLogEntry, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(v1.TransparencyLogEntry)
+	err := c.cc.Invoke(ctx, Rekor_GetEntryProof_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *rekorClient) GetTile(ctx context.Context, in *TileRequest, opts ...grpc.CallOption) (*httpbody.HttpBody, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(httpbody.HttpBody)
@@ -116,6 +129,8 @@ func (c *rekorClient) GetCheckpoint(ctx context.Context, in *emptypb.Empty, opts
 type RekorServer interface {
 	// Create an entry in the log
 	CreateEntry(context.Context, *CreateEntryRequest) (*v1.TransparencyLogEntry, error)
+	// Get an entry with an inclusion proof, once the entry has been integrated into the log
+	GetEntryProof(context.Context, *EntryProofRequest) (*v1.TransparencyLogEntry, error)
 	// Get a tile from the log
 	GetTile(context.Context, *TileRequest) (*httpbody.HttpBody, error)
 	// Get an entry bundle from the log
@@ -135,6 +150,9 @@ type UnimplementedRekorServer struct{}
 func (UnimplementedRekorServer) CreateEntry(context.Context, *CreateEntryRequest) (*v1.TransparencyLogEntry, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateEntry not implemented")
 }
+func (UnimplementedRekorServer) GetEntryProof(context.Context, *EntryProofRequest) (*v1.TransparencyLogEntry, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEntryProof not implemented")
+}
 func (UnimplementedRekorServer) GetTile(context.Context, *TileRequest) (*httpbody.HttpBody, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTile not implemented")
 }
@@ -183,6 +201,24 @@ func _Rekor_CreateEntry_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Rekor_GetEntryProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EntryProofRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RekorServer).GetEntryProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rekor_GetEntryProof_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RekorServer).GetEntryProof(ctx, req.(*EntryProofRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Rekor_GetTile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(TileRequest)
 	if err := dec(in); err != nil {
@@ -248,6 +284,10 @@ var Rekor_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateEntry",
 			Handler:    _Rekor_CreateEntry_Handler,
 		},
+		{
+			MethodName: "GetEntryProof",
+			Handler:    _Rekor_GetEntryProof_Handler,
+		},
 		{
 			MethodName: "GetTile",
 			Handler:    _Rekor_GetTile_Handler,
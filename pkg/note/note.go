@@ -33,6 +33,9 @@ import (
 	"unicode"
 	"unicode/utf8"
 
+	"github.com/cloudflare/circl/sign/mldsa/mldsa65"
+	"github.com/cloudflare/circl/sign/mldsa/mldsa87"
+	"github.com/sigstore/rekor-tiles/v2/internal/mldsa"
 	"github.com/sigstore/sigstore/pkg/signature"
 	"github.com/sigstore/sigstore/pkg/signature/options"
 	"golang.org/x/mod/sumdb/note"
@@ -136,6 +139,20 @@ func rsaKeyHash(name string, key *rsa.PublicKey) (uint32, []byte, error) {
 	return id, hash, nil
 }
 
+// mldsaKeyHash generates the 4-byte key ID for an ML-DSA public key. As for RSA keys,
+// there is no signature type for ML-DSA, so the key ID uses an identifier for the
+// parameter set.
+func mldsaKeyHash(name string, key crypto.PublicKey) (uint32, []byte, error) {
+	marshaled, err := mldsa.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return 0, nil, fmt.Errorf("marshaling public key: %w", err)
+	}
+	keyDetails, _ := mldsa.KeyDetails(key)
+	mldsaAlg := append([]byte{algUndef}, []byte("PKIX-"+mldsa.Name(keyDetails))...)
+	id, hash := genConformantKeyHash(name, mldsaAlg, marshaled)
+	return id, hash, nil
+}
+
 // KeyHash generates a truncated (4-byte) and non-truncated identifier for a
 // public key/origin
 func KeyHash(origin string, key crypto.PublicKey) (uint32, []byte, error) {
@@ -156,6 +173,11 @@ func KeyHash(origin string, key crypto.PublicKey) (uint32, []byte, error) {
 		if err != nil {
 			return 0, nil, fmt.Errorf("getting RSA key hash: %w", err)
 		}
+	case *mldsa65.PublicKey, *mldsa87.PublicKey:
+		keyID, logID, err = mldsaKeyHash(origin, pk)
+		if err != nil {
+			return 0, nil, fmt.Errorf("getting ML-DSA key hash: %w", err)
+		}
 	default:
 		return 0, nil, fmt.Errorf("unsupported key type: %T", key)
 	}
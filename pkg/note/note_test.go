@@ -18,12 +18,19 @@ package note
 
 import (
 	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
 	"encoding/hex"
 	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/cloudflare/circl/sign/mldsa/mldsa65"
+	"github.com/cloudflare/circl/sign/mldsa/mldsa87"
+	"github.com/sigstore/rekor-tiles/v2/internal/mldsa"
 	"github.com/sigstore/rekor-tiles/v2/internal/signerverifier"
 	"github.com/stretchr/testify/assert"
 )
@@ -136,6 +143,59 @@ func TestKeyHash(t *testing.T) {
 	}
 }
 
+func TestKeyHashMLDSA(t *testing.T) {
+	origin := "testkey"
+	var seed [mldsa65.SeedSize]byte
+	pub65, priv65 := mldsa65.NewKeyFromSeed(&seed)
+	pub87, priv87 := mldsa87.NewKeyFromSeed(&seed)
+	for _, test := range []struct {
+		name string
+		pub  crypto.PublicKey
+		priv crypto.Signer
+		id   string
+	}{
+		{name: "ml-dsa-65", pub: pub65, priv: priv65, id: "PKIX-ML-DSA-65"},
+		{name: "ml-dsa-87", pub: pub87, priv: priv87, id: "PKIX-ML-DSA-87"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			der, err := mldsa.MarshalPKIXPublicKey(test.pub)
+			if err != nil {
+				t.Fatal(err)
+			}
+			h := sha256.New()
+			h.Write([]byte(origin + "\n"))
+			h.Write([]byte{0xFF})
+			h.Write([]byte(test.id))
+			h.Write(der)
+			expectedLogID := h.Sum(nil)
+
+			keyID, logID, err := KeyHash(origin, test.pub)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, binary.BigEndian.Uint32(expectedLogID), keyID)
+			assert.Equal(t, expectedLogID, logID)
+
+			verifier, err := mldsa.LoadVerifier(test.pub)
+			if err != nil {
+				t.Fatal(err)
+			}
+			noteVerifier, err := NewNoteVerifier(origin, verifier)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, keyID, noteVerifier.KeyHash())
+			msg := []byte("testkey\n1\nhash\n")
+			sig, err := test.priv.Sign(rand.Reader, msg, crypto.Hash(0))
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.True(t, noteVerifier.Verify(msg, sig))
+			assert.False(t, noteVerifier.Verify([]byte("other"), sig))
+		})
+	}
+}
+
 func hexDecodeOrDie(t *testing.T, text string) []byte {
 	decoded, err := hex.DecodeString(text)
 	if err != nil {
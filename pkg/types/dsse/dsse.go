@@ -17,9 +17,9 @@ package dsse
 import (
 	"bytes"
 	"context"
+	"crypto"
 	"crypto/sha256"
 	"encoding/base64"
-	"errors"
 	"fmt"
 	"slices"
 
@@ -27,7 +27,9 @@ import (
 	v1 "github.com/sigstore/protobuf-specs/gen/pb-go/common/v1"
 	pbdsse "github.com/sigstore/protobuf-specs/gen/pb-go/dsse"
 	"github.com/sigstore/rekor-tiles/v2/internal/algorithmregistry"
+	"github.com/sigstore/rekor-tiles/v2/internal/mldsa"
 	pb "github.com/sigstore/rekor-tiles/v2/pkg/generated/protobuf"
+	"github.com/sigstore/rekor-tiles/v2/pkg/types/validation"
 	pbverifier "github.com/sigstore/rekor-tiles/v2/pkg/types/verifier"
 	"github.com/sigstore/rekor-tiles/v2/pkg/verifier"
 	"github.com/sigstore/rekor-tiles/v2/pkg/verifier/certificate"
@@ -36,18 +38,59 @@ import (
 	sigdsse "github.com/sigstore/sigstore/pkg/signature/dsse"
 )
 
-// ToLogEntry validates a request, verifies all envelope signatures, and converts it to a log entry type for inclusion in the log
-func ToLogEntry(ds *pb.DSSERequestV002, algorithmRegistry *signature.AlgorithmRegistryConfig) (*pb.Entry, error) {
+const (
+	// Kind is the kind of log entries created by ToLogEntry
+	Kind = "dsse"
+	// APIVersion is the API version of log entries created by ToLogEntry
+	APIVersion = "0.0.2"
+)
+
+// Option configures ToLogEntry.
+type Option func(*logEntryOptions)
+
+type logEntryOptions struct {
+	trustBundle *certificate.TrustBundle
+	mldsa       []v1.PublicKeyDetails
+}
+
+// WithTrustBundle requires certificates to chain to one of the trust bundle's roots.
+// A nil trust bundle accepts any certificate.
+func WithTrustBundle(trustBundle *certificate.TrustBundle) Option {
+	return func(o *logEntryOptions) {
+		o.trustBundle = trustBundle
+	}
+}
+
+// WithMLDSA allows ML-DSA signatures with the given key details, such as
+// v1.PublicKeyDetails_ML_DSA_65, in addition to the algorithms of the algorithm
+// registry. ML-DSA is experimental in Sigstore and not supported by the registry.
+func WithMLDSA(keyDetails ...v1.PublicKeyDetails) Option {
+	return func(o *logEntryOptions) {
+		o.mldsa = append(o.mldsa, keyDetails...)
+	}
+}
+
+// ToLogEntry validates a request, verifies all envelope signatures, and converts it to a log entry type for inclusion in the log.
+func ToLogEntry(ds *pb.DSSERequestV002, algorithmRegistry *signature.AlgorithmRegistryConfig, opts ...Option) (*pb.Entry, error) {
+	o := &logEntryOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	registry, err := algorithmregistry.NewRegistryFromConfig(algorithmRegistry, o.mldsa)
+	if err != nil {
+		return nil, err
+	}
+
 	if err := validate(ds); err != nil {
 		return nil, err
 	}
 
-	verifiers, err := extractVerifiers(ds)
+	verifiers, err := extractVerifiers(ds, o.trustBundle)
 	if err != nil {
 		return nil, err
 	}
 
-	signerVerifiers, err := verifyEnvelopeAndSupportedAlgs(verifiers, ds.Envelope, algorithmRegistry)
+	signerVerifiers, err := verifyEnvelopeAndSupportedAlgs(verifiers, ds.Envelope, registry)
 	if err != nil {
 		return nil, err
 	}
@@ -61,7 +104,7 @@ func ToLogEntry(ds *pb.DSSERequestV002, algorithmRegistry *signature.AlgorithmRe
 	slices.Sort(sortedSigs)
 	var canonicalizedSigs []*pb.Signature
 	for _, s := range sortedSigs {
-		canonicalizedSigs = append(canonicalizedSigs, &pb.Signature{Content: []byte(s), Verifier: signerVerifiers[s]})
+		canonicalizedSigs = append(canonicalizedSigs, &pb.Signature{Content: []byte(s), Verifier: pbverifier.WithoutIntermediates(signerVerifiers[s])})
 	}
 
 	// Use a hardcoded SHA-256 hashing algorithm for the payload hash,
@@ -70,8 +113,8 @@ func ToLogEntry(ds *pb.DSSERequestV002, algorithmRegistry *signature.AlgorithmRe
 	payloadHash := sha256.Sum256(ds.Envelope.Payload)
 
 	return &pb.Entry{
-		Kind:       "dsse",
-		ApiVersion: "0.0.2",
+		Kind:       Kind,
+		ApiVersion: APIVersion,
 		Spec: &pb.Spec{
 			Spec: &pb.Spec_DsseV002{
 				DsseV002: &pb.DSSELogEntryV002{
@@ -89,29 +132,30 @@ func ToLogEntry(ds *pb.DSSERequestV002, algorithmRegistry *signature.AlgorithmRe
 // validate validates there are no missing fields in a DSSERequestV002 protobuf
 func validate(ds *pb.DSSERequestV002) error {
 	if ds.Envelope == nil {
-		return fmt.Errorf("missing envelope")
+		return validation.Errorf(validation.ReasonMissingField, "envelope", "missing envelope")
 	}
 	if len(ds.Verifiers) == 0 {
-		return fmt.Errorf("missing verifiers")
+		return validation.Errorf(validation.ReasonMissingVerifier, "verifiers", "missing verifiers")
 	}
 	for _, v := range ds.Verifiers {
 		if err := pbverifier.Validate(v); err != nil {
-			return fmt.Errorf("invalid verifier: %v", err)
+			return validation.Errorf(validation.ReasonInvalidVerifier, "verifiers", "invalid verifier: %v", err)
 		}
 	}
 	if len(ds.Envelope.Signatures) == 0 {
-		return fmt.Errorf("envelope missing signatures")
+		return validation.Errorf(validation.ReasonMissingField, "envelope.signatures", "envelope missing signatures")
 	}
 	for _, s := range ds.Envelope.Signatures {
 		if s == nil || len(s.Sig) == 0 {
-			return fmt.Errorf("envelope signature empty")
+			return validation.Errorf(validation.ReasonMissingField, "envelope.signatures", "envelope signature empty")
 		}
 	}
 	return nil
 }
 
-// extractVerifiers returns a map of protobuf verifiers to verifier interface
-func extractVerifiers(ds *pb.DSSERequestV002) (map[*pb.Verifier]verifier.Verifier, error) {
+// extractVerifiers returns a map of protobuf verifiers to verifier interface. If trustBundle
+// is not nil, certificates must chain to one of its roots.
+func extractVerifiers(ds *pb.DSSERequestV002, trustBundle *certificate.TrustBundle) (map[*pb.Verifier]verifier.Verifier, error) {
 	verifiers := make(map[*pb.Verifier]verifier.Verifier, 0)
 	for _, v := range ds.Verifiers {
 		pubKey := v.GetPublicKey()
@@ -120,17 +164,22 @@ func extractVerifiers(ds *pb.DSSERequestV002) (map[*pb.Verifier]verifier.Verifie
 		case pubKey != nil:
 			vf, err := publickey.NewVerifier(bytes.NewReader(pubKey.RawBytes))
 			if err != nil {
-				return nil, fmt.Errorf("parsing public key: %v", err)
+				return nil, validation.Errorf(validation.ReasonInvalidVerifier, "verifiers", "parsing public key: %v", err)
 			}
 			verifiers[v] = vf
 		case cert != nil:
 			vf, err := certificate.NewVerifier(bytes.NewReader(cert.RawBytes))
 			if err != nil {
-				return nil, fmt.Errorf("parsing certificate: %v", err)
+				return nil, validation.Errorf(validation.ReasonInvalidVerifier, "verifiers", "parsing certificate: %v", err)
+			}
+			if trustBundle != nil {
+				if err := vf.VerifyChain(trustBundle, pbverifier.Intermediates(v)); err != nil {
+					return nil, validation.Errorf(validation.ReasonUntrustedCertificate, "verifiers.x509_certificate", "untrusted certificate: %w", err)
+				}
 			}
 			verifiers[v] = vf
 		default:
-			return nil, fmt.Errorf("must contain either a public key or X.509 certificate")
+			return nil, validation.Errorf(validation.ReasonMissingVerifier, "verifiers", "must contain either a public key or X.509 certificate")
 		}
 	}
 	return verifiers, nil
@@ -138,7 +187,7 @@ func extractVerifiers(ds *pb.DSSERequestV002) (map[*pb.Verifier]verifier.Verifie
 
 // verifyEnvelopeAndSupportedAlgs takes in verifiers, a map of key details to the signature verifier. Verifiers are used to
 // to verify the envelope's signatures. Returns a map of signatures to their verifiers.
-func verifyEnvelopeAndSupportedAlgs(verifiers map[*pb.Verifier]verifier.Verifier, pbenv *pbdsse.Envelope, algorithmRegistry *signature.AlgorithmRegistryConfig) (map[string]*pb.Verifier, error) {
+func verifyEnvelopeAndSupportedAlgs(verifiers map[*pb.Verifier]verifier.Verifier, pbenv *pbdsse.Envelope, algorithmRegistry *algorithmregistry.Registry) (map[string]*pb.Verifier, error) {
 	env := FromProto(pbenv)
 	savs := make(map[string]*pb.Verifier, len(verifiers))
 	// generate a fake id for these keys so we can get back to the key bytes and match them to their corresponding signature
@@ -152,34 +201,41 @@ func verifyEnvelopeAndSupportedAlgs(verifiers map[*pb.Verifier]verifier.Verifier
 			break // if all signatures have been verified, do not attempt anymore
 		}
 
-		algDetails, err := signature.GetAlgorithmDetails(v.KeyDetails)
-		if err != nil {
-			return nil, fmt.Errorf("getting key algorithm details: %w", err)
+		if err := mldsa.CheckKeyDetails(verifierKey.PublicKey(), v.KeyDetails); err != nil {
+			return nil, validation.Errorf(validation.ReasonUnsupportedAlgorithm, "verifiers.key_details", "checking key details: %w", err)
+		}
+		// ML-DSA signatures are pure, signing the PAE-encoded envelope without a digest
+		var alg crypto.Hash
+		if !mldsa.IsMLDSA(v.KeyDetails) {
+			algDetails, err := signature.GetAlgorithmDetails(v.KeyDetails)
+			if err != nil {
+				return nil, validation.Errorf(validation.ReasonUnsupportedAlgorithm, "verifiers.key_details", "getting key algorithm details: %w", err)
+			}
+			alg = algDetails.GetHashType()
 		}
-		alg := algDetails.GetHashType()
 
 		// check if signing algorithm is supported by this Rekor instance
 		valid, err := algorithmregistry.CheckEntryAlgorithms(verifierKey.PublicKey(), alg, algorithmRegistry)
 		if err != nil {
-			return nil, fmt.Errorf("checking entry algorithm: %w", err)
+			return nil, validation.Errorf(validation.ReasonUnsupportedAlgorithm, "verifiers.key_details", "checking entry algorithm: %w", err)
 		}
 		if !valid {
-			return nil, &algorithmregistry.UnsupportedAlgorithm{Pub: verifierKey.PublicKey(), Alg: alg}
+			return nil, validation.Wrap(validation.ReasonUnsupportedAlgorithm, "verifiers.key_details", &algorithmregistry.UnsupportedAlgorithm{Pub: verifierKey.PublicKey(), Alg: alg})
 		}
 
-		vfr, err := signature.LoadVerifier(verifierKey.PublicKey(), alg)
+		vfr, err := loadVerifier(verifierKey.PublicKey(), alg)
 		if err != nil {
-			return nil, fmt.Errorf("could not load verifier: %w", err)
+			return nil, validation.Errorf(validation.ReasonInvalidVerifier, "verifiers", "could not load verifier: %w", err)
 		}
 
 		dsseVfr, err := dsse.NewEnvelopeVerifier(&sigdsse.VerifierAdapter{SignatureVerifier: vfr})
 		if err != nil {
-			return nil, fmt.Errorf("could not use public key as a dsse verifier: %w", err)
+			return nil, validation.Errorf(validation.ReasonInvalidVerifier, "verifiers", "could not use public key as a dsse verifier: %w", err)
 		}
 
 		accepted, err := dsseVfr.Verify(context.Background(), env)
 		if err != nil {
-			return nil, fmt.Errorf("could not verify envelope: %w", err)
+			return nil, validation.Errorf(validation.ReasonSignatureInvalid, "envelope.signatures", "could not verify envelope: %w", err)
 		}
 
 		for _, accept := range accepted {
@@ -194,12 +250,21 @@ func verifyEnvelopeAndSupportedAlgs(verifiers map[*pb.Verifier]verifier.Verifier
 	}
 
 	if len(allSigs) > 0 {
-		return nil, errors.New("all signatures must have a key that verifies it")
+		return nil, validation.Errorf(validation.ReasonUnverifiedDSSESignature, "envelope.signatures", "all signatures must have a key that verifies it")
 	}
 
 	return savs, nil
 }
 
+// loadVerifier returns a signature verifier for the public key, supporting ML-DSA keys
+// as well as the key types supported by sigstore.
+func loadVerifier(pub crypto.PublicKey, alg crypto.Hash) (signature.Verifier, error) {
+	if _, ok := mldsa.KeyDetails(pub); ok {
+		return mldsa.LoadVerifier(pub)
+	}
+	return signature.LoadVerifier(pub, alg)
+}
+
 // FromProto converts a dsse proto message to a dsse struct
 func FromProto(env *pbdsse.Envelope) *dsse.Envelope {
 	var newEnv dsse.Envelope
@@ -15,18 +15,29 @@
 package dsse
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/sha256"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
 	"encoding/pem"
 	"fmt"
 	"testing"
 
+	"github.com/cloudflare/circl/sign/mldsa/mldsa65"
+	"github.com/cloudflare/circl/sign/mldsa/mldsa87"
 	"github.com/go-test/deep"
 	dsset "github.com/secure-systems-lab/go-securesystemslib/dsse"
 	v1 "github.com/sigstore/protobuf-specs/gen/pb-go/common/v1"
 	"github.com/sigstore/protobuf-specs/gen/pb-go/dsse"
+	"github.com/sigstore/rekor-tiles/v2/internal/mldsa"
+	"github.com/sigstore/rekor-tiles/v2/internal/testutil"
 	pb "github.com/sigstore/rekor-tiles/v2/pkg/generated/protobuf"
+	"github.com/sigstore/rekor-tiles/v2/pkg/types/validation"
+	"github.com/sigstore/rekor-tiles/v2/pkg/verifier/certificate"
 	"github.com/sigstore/sigstore/pkg/signature"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/protobuf/encoding/protojson"
@@ -88,6 +99,7 @@ func TestToLogEntry(t *testing.T) {
 		dsse              *pb.DSSERequestV002
 		allowedAlgorithms []v1.PublicKeyDetails
 		expectErr         error
+		expectReason      validation.Reason
 		expectedEntry     *pb.Entry
 	}{
 		{
@@ -156,7 +168,8 @@ func TestToLogEntry(t *testing.T) {
 					},
 				},
 			},
-			expectErr: fmt.Errorf("missing envelope"),
+			expectErr:    fmt.Errorf("missing envelope"),
+			expectReason: validation.ReasonMissingField,
 		},
 		{
 			name: "missing verifiers",
@@ -172,10 +185,11 @@ func TestToLogEntry(t *testing.T) {
 					},
 				},
 			},
-			expectErr: fmt.Errorf("missing verifiers"),
+			expectErr:    fmt.Errorf("missing verifiers"),
+			expectReason: validation.ReasonMissingVerifier,
 		},
 		{
-			name: "invalid verifier",
+			name: "verifier with key details but no key bytes",
 			dsse: &pb.DSSERequestV002{
 				Envelope: &dsse.Envelope{
 					Payload:     []byte("payload"),
@@ -196,7 +210,8 @@ func TestToLogEntry(t *testing.T) {
 					},
 				},
 			},
-			expectErr: fmt.Errorf("invalid verifier"),
+			expectErr:    fmt.Errorf("invalid verifier"),
+			expectReason: validation.ReasonInvalidVerifier,
 		},
 		{
 			name: "missing signatures",
@@ -216,7 +231,8 @@ func TestToLogEntry(t *testing.T) {
 					},
 				},
 			},
-			expectErr: fmt.Errorf("envelope missing signatures"),
+			expectErr:    fmt.Errorf("envelope missing signatures"),
+			expectReason: validation.ReasonMissingField,
 		},
 		{
 			name: "empty signatures",
@@ -237,7 +253,8 @@ func TestToLogEntry(t *testing.T) {
 					},
 				},
 			},
-			expectErr: fmt.Errorf("envelope missing signatures"),
+			expectErr:    fmt.Errorf("envelope missing signatures"),
+			expectReason: validation.ReasonMissingField,
 		},
 		{
 			name: "invalid signature",
@@ -263,7 +280,39 @@ func TestToLogEntry(t *testing.T) {
 					},
 				},
 			},
-			expectErr: fmt.Errorf("could not verify envelope: accepted signatures do not match threshold, Found: 0, Expected 1"),
+			expectErr:    fmt.Errorf("could not verify envelope: accepted signatures do not match threshold, Found: 0, Expected 1"),
+			expectReason: validation.ReasonSignatureInvalid,
+		},
+		{
+			name: "signature without a verifier",
+			dsse: &pb.DSSERequestV002{
+				Envelope: &dsse.Envelope{
+					Payload:     payload,
+					PayloadType: "application/vnd.in-toto+json",
+					Signatures: []*dsse.Signature{
+						{
+							Sig:   keySignature,
+							Keyid: "",
+						},
+						{
+							Sig:   certSignature,
+							Keyid: "",
+						},
+					},
+				},
+				Verifiers: []*pb.Verifier{
+					{
+						Verifier: &pb.Verifier_PublicKey{
+							PublicKey: &pb.PublicKey{
+								RawBytes: []byte(publicKey),
+							},
+						},
+						KeyDetails: v1.PublicKeyDetails_PKIX_ECDSA_P256_SHA_256,
+					},
+				},
+			},
+			expectErr:    fmt.Errorf("all signatures must have a key that verifies it"),
+			expectReason: validation.ReasonUnverifiedDSSESignature,
 		},
 		{
 			name: "valid dsse with X.509 cert",
@@ -343,6 +392,7 @@ func TestToLogEntry(t *testing.T) {
 			},
 			allowedAlgorithms: []v1.PublicKeyDetails{v1.PublicKeyDetails_PKIX_RSA_PKCS1V15_4096_SHA256, v1.PublicKeyDetails_PKIX_ED25519_PH},
 			expectErr:         fmt.Errorf("unsupported entry algorithm for ECDSA key, curve P-256, digest SHA-256"),
+			expectReason:      validation.ReasonUnsupportedAlgorithm,
 		},
 		{
 			name: "valid DSSE with multiple signatures, different algorithm",
@@ -515,6 +565,7 @@ func TestToLogEntry(t *testing.T) {
 				}
 			} else {
 				assert.ErrorContains(t, gotErr, test.expectErr.Error())
+				assert.Equal(t, test.expectReason, validation.ReasonOf(gotErr))
 			}
 		})
 	}
@@ -576,3 +627,205 @@ func b64DecodeOrDie(t *testing.T, msg string) []byte {
 	}
 	return decoded
 }
+
+func TestToLogEntryTrustBundle(t *testing.T) {
+	root, intermediate, leaf, leafKey := testutil.NewCertChain(t)
+	trustBundle, err := certificate.NewTrustBundle([]*x509.Certificate{root}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	algReg, err := signature.NewAlgorithmRegistryConfig([]v1.PublicKeyDetails{v1.PublicKeyDetails_PKIX_ECDSA_P256_SHA_256})
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload := []byte("payload")
+	digest := sha256.Sum256(dsset.PAE("text/plain", payload))
+	sig, err := ecdsa.SignASN1(rand.Reader, leafKey, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	request := func(intermediates ...*x509.Certificate) *pb.DSSERequestV002 {
+		verifier := &pb.Verifier{
+			Verifier:   &pb.Verifier_X509Certificate{X509Certificate: &v1.X509Certificate{RawBytes: leaf.Raw}},
+			KeyDetails: v1.PublicKeyDetails_PKIX_ECDSA_P256_SHA_256,
+		}
+		for _, c := range intermediates {
+			verifier.Intermediates = append(verifier.Intermediates, &v1.X509Certificate{RawBytes: c.Raw})
+		}
+		return &pb.DSSERequestV002{
+			Envelope: &dsse.Envelope{
+				Payload:     payload,
+				PayloadType: "text/plain",
+				Signatures:  []*dsse.Signature{{Sig: sig}},
+			},
+			Verifiers: []*pb.Verifier{verifier},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		dsse         *pb.DSSERequestV002
+		trustBundle  *certificate.TrustBundle
+		expectErr    error
+		expectReason validation.Reason
+	}{
+		{
+			name: "no trust bundle",
+			dsse: request(),
+		},
+		{
+			name:        "chains to trusted root",
+			dsse:        request(intermediate),
+			trustBundle: trustBundle,
+		},
+		{
+			name:         "missing intermediate",
+			dsse:         request(),
+			trustBundle:  trustBundle,
+			expectErr:    fmt.Errorf("untrusted certificate"),
+			expectReason: validation.ReasonUntrustedCertificate,
+		},
+		{
+			name:         "invalid intermediate",
+			dsse:         request(&x509.Certificate{Raw: []byte("not a certificate")}),
+			trustBundle:  trustBundle,
+			expectErr:    fmt.Errorf("parsing intermediate certificate"),
+			expectReason: validation.ReasonUntrustedCertificate,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			entry, gotErr := ToLogEntry(test.dsse, algReg, WithTrustBundle(test.trustBundle))
+			if test.expectErr == nil {
+				if assert.NoError(t, gotErr) {
+					verifier := entry.GetSpec().GetDsseV002().GetSignatures()[0].GetVerifier()
+					assert.Equal(t, leaf.Raw, verifier.GetX509Certificate().GetRawBytes())
+					assert.Empty(t, verifier.GetIntermediates())
+				}
+			} else {
+				assert.ErrorContains(t, gotErr, test.expectErr.Error())
+				assert.Equal(t, test.expectReason, validation.ReasonOf(gotErr))
+			}
+		})
+	}
+}
+
+func TestToLogEntryMLDSA(t *testing.T) {
+	pub65, priv65, err := mldsa65.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub87, priv87, err := mldsa87.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der65, err := mldsa.MarshalPKIXPublicKey(pub65)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der87, err := mldsa.MarshalPKIXPublicKey(pub87)
+	if err != nil {
+		t.Fatal(err)
+	}
+	derECDSA, err := x509.MarshalPKIXPublicKey(ecdsaKey.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifier := func(der []byte, keyDetails v1.PublicKeyDetails) *pb.Verifier {
+		return &pb.Verifier{
+			Verifier:   &pb.Verifier_PublicKey{PublicKey: &pb.PublicKey{RawBytes: der}},
+			KeyDetails: keyDetails,
+		}
+	}
+	payload := []byte("payload")
+	pae := dsset.PAE("text/plain", payload)
+	sign := func(priv crypto.Signer, msg []byte) *dsse.Signature {
+		sig, err := priv.Sign(rand.Reader, msg, crypto.Hash(0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &dsse.Signature{Sig: sig}
+	}
+	digest := sha256.Sum256(pae)
+	ecdsaSig, err := ecdsa.SignASN1(rand.Reader, ecdsaKey, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	request := func(sigs []*dsse.Signature, verifiers ...*pb.Verifier) *pb.DSSERequestV002 {
+		return &pb.DSSERequestV002{
+			Envelope:  &dsse.Envelope{Payload: payload, PayloadType: "text/plain", Signatures: sigs},
+			Verifiers: verifiers,
+		}
+	}
+
+	tests := []struct {
+		name         string
+		dsse         *pb.DSSERequestV002
+		allowedMLDSA []v1.PublicKeyDetails
+		expectErr    error
+		expectReason validation.Reason
+	}{
+		{
+			name: "valid ml-dsa-65",
+			dsse: request([]*dsse.Signature{sign(priv65, pae)}, verifier(der65, v1.PublicKeyDetails_ML_DSA_65)),
+		},
+		{
+			name: "ml-dsa-87 and ecdsa signatures",
+			dsse: request([]*dsse.Signature{sign(priv87, pae), {Sig: ecdsaSig}},
+				verifier(der87, v1.PublicKeyDetails_ML_DSA_87), verifier(derECDSA, v1.PublicKeyDetails_PKIX_ECDSA_P256_SHA_256)),
+		},
+		{
+			name:         "ml-dsa not allowed",
+			dsse:         request([]*dsse.Signature{sign(priv65, pae)}, verifier(der65, v1.PublicKeyDetails_ML_DSA_65)),
+			allowedMLDSA: []v1.PublicKeyDetails{},
+			expectErr:    fmt.Errorf("unsupported entry algorithm for ML-DSA key, parameter set ML-DSA-65"),
+			expectReason: validation.ReasonUnsupportedAlgorithm,
+		},
+		{
+			name:         "mismatched parameter set",
+			dsse:         request([]*dsse.Signature{sign(priv65, pae)}, verifier(der65, v1.PublicKeyDetails_ML_DSA_87)),
+			expectErr:    fmt.Errorf("do not match"),
+			expectReason: validation.ReasonUnsupportedAlgorithm,
+		},
+		{
+			name:         "signature over payload rather than PAE",
+			dsse:         request([]*dsse.Signature{sign(priv65, payload)}, verifier(der65, v1.PublicKeyDetails_ML_DSA_65)),
+			expectErr:    fmt.Errorf("could not verify envelope"),
+			expectReason: validation.ReasonSignatureInvalid,
+		},
+	}
+	algReg, err := signature.NewAlgorithmRegistryConfig([]v1.PublicKeyDetails{v1.PublicKeyDetails_PKIX_ECDSA_P256_SHA_256})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			allowedMLDSA := test.allowedMLDSA
+			if allowedMLDSA == nil {
+				allowedMLDSA = []v1.PublicKeyDetails{v1.PublicKeyDetails_ML_DSA_65, v1.PublicKeyDetails_ML_DSA_87}
+			}
+			entry, gotErr := ToLogEntry(test.dsse, algReg, WithMLDSA(allowedMLDSA...))
+			if test.expectErr == nil {
+				if assert.NoError(t, gotErr) {
+					assert.Len(t, entry.GetSpec().GetDsseV002().GetSignatures(), len(test.dsse.Envelope.Signatures))
+				}
+			} else {
+				assert.ErrorContains(t, gotErr, test.expectErr.Error())
+				assert.Equal(t, test.expectReason, validation.ReasonOf(gotErr))
+			}
+		})
+	}
+
+	t.Run("nil registry", func(t *testing.T) {
+		_, err := ToLogEntry(request([]*dsse.Signature{sign(priv65, pae)}, verifier(der65, v1.PublicKeyDetails_ML_DSA_65)), nil, WithMLDSA(v1.PublicKeyDetails_ML_DSA_65))
+		assert.ErrorContains(t, err, "missing algorithm registry")
+	})
+	t.Run("non-ml-dsa algorithm", func(t *testing.T) {
+		_, err := ToLogEntry(request([]*dsse.Signature{sign(priv65, pae)}, verifier(der65, v1.PublicKeyDetails_ML_DSA_65)), algReg, WithMLDSA(v1.PublicKeyDetails_PKIX_ED25519))
+		assert.ErrorContains(t, err, "not an ML-DSA algorithm")
+	})
+}
@@ -17,11 +17,12 @@ package hashedrekord
 import (
 	"bytes"
 	"crypto"
-	"fmt"
 
 	v1 "github.com/sigstore/protobuf-specs/gen/pb-go/common/v1"
 	"github.com/sigstore/rekor-tiles/v2/internal/algorithmregistry"
+	"github.com/sigstore/rekor-tiles/v2/internal/mldsa"
 	pb "github.com/sigstore/rekor-tiles/v2/pkg/generated/protobuf"
+	"github.com/sigstore/rekor-tiles/v2/pkg/types/validation"
 	pbverifier "github.com/sigstore/rekor-tiles/v2/pkg/types/verifier"
 	"github.com/sigstore/rekor-tiles/v2/pkg/verifier"
 	"github.com/sigstore/rekor-tiles/v2/pkg/verifier/certificate"
@@ -30,34 +31,78 @@ import (
 	"github.com/sigstore/sigstore/pkg/signature/options"
 )
 
-// ToLogEntry validates a request, verifies its signature, and converts it to a log entry type for inclusion in the log
-func ToLogEntry(hr *pb.HashedRekordRequestV002, algorithmRegistry *signature.AlgorithmRegistryConfig) (*pb.Entry, error) {
+const (
+	// Kind is the kind of log entries created by ToLogEntry
+	Kind = "hashedrekord"
+	// APIVersion is the API version of log entries created by ToLogEntry
+	APIVersion = "0.0.2"
+	// MLDSADigestAlgorithm is the digest algorithm of entries signed with ML-DSA. ML-DSA
+	// signatures are pure, so the signed message is the artifact digest itself.
+	MLDSADigestAlgorithm = crypto.SHA512
+)
+
+// Option configures ToLogEntry.
+type Option func(*logEntryOptions)
+
+type logEntryOptions struct {
+	trustBundle *certificate.TrustBundle
+	mldsa       []v1.PublicKeyDetails
+}
+
+// WithTrustBundle requires certificates to chain to one of the trust bundle's roots.
+// A nil trust bundle accepts any certificate.
+func WithTrustBundle(trustBundle *certificate.TrustBundle) Option {
+	return func(o *logEntryOptions) {
+		o.trustBundle = trustBundle
+	}
+}
+
+// WithMLDSA allows ML-DSA signatures with the given key details, such as
+// v1.PublicKeyDetails_ML_DSA_65, in addition to the algorithms of the algorithm
+// registry. ML-DSA is experimental in Sigstore and not supported by the registry.
+func WithMLDSA(keyDetails ...v1.PublicKeyDetails) Option {
+	return func(o *logEntryOptions) {
+		o.mldsa = append(o.mldsa, keyDetails...)
+	}
+}
+
+// ToLogEntry validates a request, verifies its signature, and converts it to a log entry type for inclusion in the log.
+func ToLogEntry(hr *pb.HashedRekordRequestV002, algorithmRegistry *signature.AlgorithmRegistryConfig, opts ...Option) (*pb.Entry, error) {
+	o := &logEntryOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	registry, err := algorithmregistry.NewRegistryFromConfig(algorithmRegistry, o.mldsa)
+	if err != nil {
+		return nil, err
+	}
+
 	if err := validate(hr); err != nil {
 		return nil, err
 	}
 
-	v, err := extractVerifier(hr)
+	v, err := extractVerifier(hr, o.trustBundle)
 	if err != nil {
 		return nil, err
 	}
 
-	algDetails, err := verifySupportedAlgorithm(hr.Signature.Verifier.KeyDetails, v, algorithmRegistry)
+	hashAlg, protoHashAlg, err := verifySupportedAlgorithm(hr.Signature.Verifier.KeyDetails, v, registry)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := verifySignature(hr, v, algDetails.GetHashType()); err != nil {
+	if err := verifySignature(hr, v, hashAlg); err != nil {
 		return nil, err
 	}
 
 	return &pb.Entry{
-		Kind:       "hashedrekord",
-		ApiVersion: "0.0.2",
+		Kind:       Kind,
+		ApiVersion: APIVersion,
 		Spec: &pb.Spec{
 			Spec: &pb.Spec_HashedRekordV002{
 				HashedRekordV002: &pb.HashedRekordLogEntryV002{
-					Signature: hr.Signature,
-					Data:      &v1.HashOutput{Digest: hr.Digest, Algorithm: algDetails.GetProtoHashType()},
+					Signature: &pb.Signature{Content: hr.Signature.Content, Verifier: pbverifier.WithoutIntermediates(hr.Signature.Verifier)},
+					Data:      &v1.HashOutput{Digest: hr.Digest, Algorithm: protoHashAlg},
 				},
 			},
 		},
@@ -67,63 +112,97 @@ func ToLogEntry(hr *pb.HashedRekordRequestV002, algorithmRegistry *signature.Alg
 // validate validates there are no missing fields in a HashedRekordRequestV002 protobuf
 func validate(hr *pb.HashedRekordRequestV002) error {
 	if hr.Signature == nil || len(hr.Signature.Content) == 0 {
-		return fmt.Errorf("missing signature")
+		return validation.Errorf(validation.ReasonMissingField, "signature.content", "missing signature")
 	}
 	if hr.Signature.Verifier == nil {
-		return fmt.Errorf("missing verifier")
+		return validation.Errorf(validation.ReasonMissingVerifier, "signature.verifier", "missing verifier")
 	}
 	if len(hr.Digest) == 0 {
-		return fmt.Errorf("missing digest")
+		return validation.Errorf(validation.ReasonMissingField, "digest", "missing digest")
 	}
 	if err := pbverifier.Validate(hr.Signature.Verifier); err != nil {
-		return fmt.Errorf("invalid verifier: %v", err)
+		return validation.Errorf(validation.ReasonInvalidVerifier, "signature.verifier", "invalid verifier: %v", err)
 	}
 	return nil
 }
 
-func extractVerifier(hr *pb.HashedRekordRequestV002) (verifier.Verifier, error) {
-	var v verifier.Verifier
-	var err error
+func extractVerifier(hr *pb.HashedRekordRequestV002, trustBundle *certificate.TrustBundle) (verifier.Verifier, error) {
 	if pubKey := hr.Signature.Verifier.GetPublicKey(); pubKey != nil {
-		v, err = publickey.NewVerifier(bytes.NewReader(pubKey.RawBytes))
-	} else if cert := hr.Signature.Verifier.GetX509Certificate(); cert != nil {
-		v, err = certificate.NewVerifier(bytes.NewReader(cert.RawBytes))
-	} else {
-		return nil, fmt.Errorf("must contain either a public key or X.509 certificate")
+		v, err := publickey.NewVerifier(bytes.NewReader(pubKey.RawBytes))
+		if err != nil {
+			return nil, validation.Errorf(validation.ReasonInvalidVerifier, "signature.verifier", "parsing verifier: %w", err)
+		}
+		return v, nil
 	}
-	if err != nil {
-		return nil, fmt.Errorf("parsing verifier: %w", err)
+	if cert := hr.Signature.Verifier.GetX509Certificate(); cert != nil {
+		v, err := certificate.NewVerifier(bytes.NewReader(cert.RawBytes))
+		if err != nil {
+			return nil, validation.Errorf(validation.ReasonInvalidVerifier, "signature.verifier", "parsing verifier: %w", err)
+		}
+		if trustBundle != nil {
+			if err := v.VerifyChain(trustBundle, pbverifier.Intermediates(hr.Signature.Verifier)); err != nil {
+				return nil, validation.Errorf(validation.ReasonUntrustedCertificate, "signature.verifier.x509_certificate", "untrusted certificate: %w", err)
+			}
+		}
+		return v, nil
 	}
-	return v, nil
+	return nil, validation.Errorf(validation.ReasonMissingVerifier, "signature.verifier", "must contain either a public key or X.509 certificate")
 }
 
 // verifySupportedAlgorithm confirms that the signature and digest algorithm pair is supported by this server
-// instance, and returns details about the signing algorithm to be used while verifying the entry signature.
-func verifySupportedAlgorithm(keyDetails v1.PublicKeyDetails, v verifier.Verifier, algorithmRegistry *signature.AlgorithmRegistryConfig) (signature.AlgorithmDetails, error) {
-	algDetails, err := signature.GetAlgorithmDetails(keyDetails)
-	if err != nil {
-		return signature.AlgorithmDetails{}, fmt.Errorf("getting key algorithm details: %w", err)
+// instance, and returns the digest algorithm to be used while verifying the entry signature.
+func verifySupportedAlgorithm(keyDetails v1.PublicKeyDetails, v verifier.Verifier, algorithmRegistry *algorithmregistry.Registry) (crypto.Hash, v1.HashAlgorithm, error) {
+	if err := mldsa.CheckKeyDetails(v.PublicKey(), keyDetails); err != nil {
+		return 0, 0, validation.Errorf(validation.ReasonUnsupportedAlgorithm, "signature.verifier.key_details", "checking key details: %w", err)
+	}
+	var alg crypto.Hash
+	var protoAlg v1.HashAlgorithm
+	if mldsa.IsMLDSA(keyDetails) {
+		alg, protoAlg = MLDSADigestAlgorithm, v1.HashAlgorithm_SHA2_512
+	} else {
+		algDetails, err := signature.GetAlgorithmDetails(keyDetails)
+		if err != nil {
+			return 0, 0, validation.Errorf(validation.ReasonUnsupportedAlgorithm, "signature.verifier.key_details", "getting key algorithm details: %w", err)
+		}
+		alg, protoAlg = algDetails.GetHashType(), algDetails.GetProtoHashType()
 	}
-	alg := algDetails.GetHashType()
 
 	valid, err := algorithmregistry.CheckEntryAlgorithms(v.PublicKey(), alg, algorithmRegistry)
 	if err != nil {
-		return signature.AlgorithmDetails{}, fmt.Errorf("checking entry algorithm: %w", err)
+		return 0, 0, validation.Errorf(validation.ReasonUnsupportedAlgorithm, "signature.verifier.key_details", "checking entry algorithm: %w", err)
 	}
 	if !valid {
-		return signature.AlgorithmDetails{}, &algorithmregistry.UnsupportedAlgorithm{Pub: v.PublicKey(), Alg: alg}
+		return 0, 0, validation.Wrap(validation.ReasonUnsupportedAlgorithm, "signature.verifier.key_details", &algorithmregistry.UnsupportedAlgorithm{Pub: v.PublicKey(), Alg: alg})
 	}
-	return algDetails, nil
+	return alg, protoAlg, nil
 }
 
 func verifySignature(hr *pb.HashedRekordRequestV002, v verifier.Verifier, hashAlg crypto.Hash) error {
+	if _, ok := mldsa.KeyDetails(v.PublicKey()); ok {
+		return verifyMLDSASignature(hr, v)
+	}
 	sigVerifier, err := signature.LoadVerifierWithOpts(v.PublicKey(), options.WithED25519ph())
 	if err != nil {
-		return fmt.Errorf("loading verifier: %v", err)
+		return validation.Errorf(validation.ReasonInvalidVerifier, "signature.verifier", "loading verifier: %v", err)
 	}
 	if err := sigVerifier.VerifySignature(
 		bytes.NewReader(hr.Signature.Content), nil, options.WithDigest(hr.Digest), options.WithCryptoSignerOpts(hashAlg)); err != nil {
-		return fmt.Errorf("verifying signature: %w", err)
+		return validation.Errorf(validation.ReasonSignatureInvalid, "signature.content", "verifying signature: %w", err)
+	}
+	return nil
+}
+
+// verifyMLDSASignature verifies a pure ML-DSA signature over the artifact digest.
+func verifyMLDSASignature(hr *pb.HashedRekordRequestV002, v verifier.Verifier) error {
+	if len(hr.Digest) != MLDSADigestAlgorithm.Size() {
+		return validation.Errorf(validation.ReasonUnsupportedAlgorithm, "digest", "ML-DSA entries require a %s digest", MLDSADigestAlgorithm)
+	}
+	sigVerifier, err := mldsa.LoadVerifier(v.PublicKey())
+	if err != nil {
+		return validation.Errorf(validation.ReasonInvalidVerifier, "signature.verifier", "loading verifier: %v", err)
+	}
+	if err := sigVerifier.VerifySignature(bytes.NewReader(hr.Signature.Content), bytes.NewReader(hr.Digest)); err != nil {
+		return validation.Errorf(validation.ReasonSignatureInvalid, "signature.content", "verifying signature: %w", err)
 	}
 	return nil
 }
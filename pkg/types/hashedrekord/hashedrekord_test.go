@@ -15,15 +15,27 @@
 package hashedrekord
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/pem"
 	"fmt"
 	"testing"
 
+	"github.com/cloudflare/circl/sign/mldsa/mldsa65"
+	"github.com/cloudflare/circl/sign/mldsa/mldsa87"
 	"github.com/go-test/deep"
 	v1 "github.com/sigstore/protobuf-specs/gen/pb-go/common/v1"
+	"github.com/sigstore/rekor-tiles/v2/internal/mldsa"
+	"github.com/sigstore/rekor-tiles/v2/internal/testutil"
 	pb "github.com/sigstore/rekor-tiles/v2/pkg/generated/protobuf"
+	"github.com/sigstore/rekor-tiles/v2/pkg/types/validation"
+	"github.com/sigstore/rekor-tiles/v2/pkg/verifier/certificate"
 	"github.com/sigstore/sigstore/pkg/signature"
 	"github.com/stretchr/testify/assert"
 )
@@ -82,6 +94,7 @@ func TestToLogEntry(t *testing.T) {
 		hashedrekord      *pb.HashedRekordRequestV002
 		allowedAlgorithms []v1.PublicKeyDetails
 		expectErr         error
+		expectReason      validation.Reason
 		expectedEntry     *pb.Entry
 	}{
 		{
@@ -141,7 +154,8 @@ func TestToLogEntry(t *testing.T) {
 				},
 				Digest: hexDecodeOrDie(t, hexEncodedDigest),
 			},
-			expectErr: fmt.Errorf("missing signature"),
+			expectErr:    fmt.Errorf("missing signature"),
+			expectReason: validation.ReasonMissingField,
 		},
 		{
 			name: "missing verifier",
@@ -151,7 +165,8 @@ func TestToLogEntry(t *testing.T) {
 				},
 				Digest: hexDecodeOrDie(t, hexEncodedDigest),
 			},
-			expectErr: fmt.Errorf("missing verifier"),
+			expectErr:    fmt.Errorf("missing verifier"),
+			expectReason: validation.ReasonMissingVerifier,
 		},
 		{
 			name: "missing digest",
@@ -168,10 +183,11 @@ func TestToLogEntry(t *testing.T) {
 					},
 				},
 			},
-			expectErr: fmt.Errorf("missing digest"),
+			expectErr:    fmt.Errorf("missing digest"),
+			expectReason: validation.ReasonMissingField,
 		},
 		{
-			name: "invalid verifier",
+			name: "verifier with key details but no key bytes",
 			hashedrekord: &pb.HashedRekordRequestV002{
 				Signature: &pb.Signature{
 					Content: []byte("sig"),
@@ -184,7 +200,8 @@ func TestToLogEntry(t *testing.T) {
 				},
 				Digest: []byte("digest"),
 			},
-			expectErr: fmt.Errorf("invalid verifier"),
+			expectErr:    fmt.Errorf("invalid verifier"),
+			expectReason: validation.ReasonInvalidVerifier,
 		},
 		{
 			name: "invalid signature",
@@ -202,7 +219,8 @@ func TestToLogEntry(t *testing.T) {
 				},
 				Digest: hexDecodeOrDie(t, hexEncodedDigest),
 			},
-			expectErr: fmt.Errorf("verifying signature: "),
+			expectErr:    fmt.Errorf("verifying signature: "),
+			expectReason: validation.ReasonSignatureInvalid,
 		},
 		{
 			name: "valid hashedrekord with X.509 cert",
@@ -264,6 +282,7 @@ func TestToLogEntry(t *testing.T) {
 			},
 			allowedAlgorithms: []v1.PublicKeyDetails{v1.PublicKeyDetails_PKIX_RSA_PKCS1V15_4096_SHA256, v1.PublicKeyDetails_PKIX_ED25519_PH},
 			expectErr:         fmt.Errorf("unsupported entry algorithm for ECDSA key, curve P-256, digest SHA-256"),
+			expectReason:      validation.ReasonUnsupportedAlgorithm,
 		},
 		{
 			name: "valid hashedrekord with different algorithm",
@@ -326,6 +345,7 @@ func TestToLogEntry(t *testing.T) {
 				}
 			} else {
 				assert.ErrorContains(t, gotErr, test.expectErr.Error())
+				assert.Equal(t, test.expectReason, validation.ReasonOf(gotErr))
 			}
 		})
 	}
@@ -346,3 +366,209 @@ func b64DecodeOrDie(t *testing.T, msg string) []byte {
 	}
 	return decoded
 }
+
+func TestToLogEntryTrustBundle(t *testing.T) {
+	root, intermediate, leaf, leafKey := testutil.NewCertChain(t)
+	trustBundle, err := certificate.NewTrustBundle([]*x509.Certificate{root}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	algReg, err := signature.NewAlgorithmRegistryConfig([]v1.PublicKeyDetails{v1.PublicKeyDetails_PKIX_ECDSA_P256_SHA_256})
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256([]byte("artifact"))
+	sig, err := ecdsa.SignASN1(rand.Reader, leafKey, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	request := func(intermediates ...*x509.Certificate) *pb.HashedRekordRequestV002 {
+		verifier := &pb.Verifier{
+			Verifier:   &pb.Verifier_X509Certificate{X509Certificate: &v1.X509Certificate{RawBytes: leaf.Raw}},
+			KeyDetails: v1.PublicKeyDetails_PKIX_ECDSA_P256_SHA_256,
+		}
+		for _, c := range intermediates {
+			verifier.Intermediates = append(verifier.Intermediates, &v1.X509Certificate{RawBytes: c.Raw})
+		}
+		return &pb.HashedRekordRequestV002{Signature: &pb.Signature{Content: sig, Verifier: verifier}, Digest: digest[:]}
+	}
+
+	tests := []struct {
+		name         string
+		hashedrekord *pb.HashedRekordRequestV002
+		trustBundle  *certificate.TrustBundle
+		expectErr    error
+		expectReason validation.Reason
+	}{
+		{
+			name:         "no trust bundle",
+			hashedrekord: request(),
+		},
+		{
+			name:         "chains to trusted root",
+			hashedrekord: request(intermediate),
+			trustBundle:  trustBundle,
+		},
+		{
+			name:         "missing intermediate",
+			hashedrekord: request(),
+			trustBundle:  trustBundle,
+			expectErr:    fmt.Errorf("untrusted certificate"),
+			expectReason: validation.ReasonUntrustedCertificate,
+		},
+		{
+			name: "intermediates with public key",
+			hashedrekord: &pb.HashedRekordRequestV002{
+				Signature: &pb.Signature{
+					Content: sig,
+					Verifier: &pb.Verifier{
+						Verifier:      &pb.Verifier_PublicKey{PublicKey: &pb.PublicKey{RawBytes: []byte("key")}},
+						KeyDetails:    v1.PublicKeyDetails_PKIX_ECDSA_P256_SHA_256,
+						Intermediates: []*v1.X509Certificate{{RawBytes: intermediate.Raw}},
+					},
+				},
+				Digest: digest[:],
+			},
+			trustBundle:  trustBundle,
+			expectErr:    fmt.Errorf("intermediate certificates require an X.509 certificate"),
+			expectReason: validation.ReasonInvalidVerifier,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			entry, gotErr := ToLogEntry(test.hashedrekord, algReg, WithTrustBundle(test.trustBundle))
+			if test.expectErr == nil {
+				if assert.NoError(t, gotErr) {
+					verifier := entry.GetSpec().GetHashedRekordV002().GetSignature().GetVerifier()
+					assert.Equal(t, leaf.Raw, verifier.GetX509Certificate().GetRawBytes())
+					assert.Empty(t, verifier.GetIntermediates())
+				}
+			} else {
+				assert.ErrorContains(t, gotErr, test.expectErr.Error())
+				assert.Equal(t, test.expectReason, validation.ReasonOf(gotErr))
+			}
+		})
+	}
+}
+
+func TestToLogEntryMLDSA(t *testing.T) {
+	pub65, priv65, err := mldsa65.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pub87, priv87, err := mldsa87.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der65, err := mldsa.MarshalPKIXPublicKey(pub65)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der87, err := mldsa.MarshalPKIXPublicKey(pub87)
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := sha512.Sum512([]byte("firmware"))
+	sign := func(priv crypto.Signer, msg []byte) []byte {
+		sig, err := priv.Sign(rand.Reader, msg, crypto.Hash(0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sig
+	}
+	request := func(der, sig []byte, keyDetails v1.PublicKeyDetails, digest []byte) *pb.HashedRekordRequestV002 {
+		return &pb.HashedRekordRequestV002{
+			Signature: &pb.Signature{
+				Content: sig,
+				Verifier: &pb.Verifier{
+					Verifier:   &pb.Verifier_PublicKey{PublicKey: &pb.PublicKey{RawBytes: der}},
+					KeyDetails: keyDetails,
+				},
+			},
+			Digest: digest,
+		}
+	}
+	sha256Digest := sha256.Sum256([]byte("firmware"))
+	otherDigest := sha512.Sum512([]byte("other"))
+
+	tests := []struct {
+		name         string
+		hashedrekord *pb.HashedRekordRequestV002
+		allowedMLDSA []v1.PublicKeyDetails
+		expectErr    error
+		expectReason validation.Reason
+	}{
+		{
+			name:         "valid ml-dsa-65",
+			hashedrekord: request(der65, sign(priv65, digest[:]), v1.PublicKeyDetails_ML_DSA_65, digest[:]),
+		},
+		{
+			name:         "valid ml-dsa-87",
+			hashedrekord: request(der87, sign(priv87, digest[:]), v1.PublicKeyDetails_ML_DSA_87, digest[:]),
+		},
+		{
+			name:         "ml-dsa not allowed",
+			hashedrekord: request(der87, sign(priv87, digest[:]), v1.PublicKeyDetails_ML_DSA_87, digest[:]),
+			allowedMLDSA: []v1.PublicKeyDetails{v1.PublicKeyDetails_ML_DSA_65},
+			expectErr:    fmt.Errorf("unsupported entry algorithm for ML-DSA key, parameter set ML-DSA-87"),
+			expectReason: validation.ReasonUnsupportedAlgorithm,
+		},
+		{
+			name:         "mismatched parameter set",
+			hashedrekord: request(der65, sign(priv65, digest[:]), v1.PublicKeyDetails_ML_DSA_87, digest[:]),
+			expectErr:    fmt.Errorf("do not match"),
+			expectReason: validation.ReasonUnsupportedAlgorithm,
+		},
+		{
+			name:         "ml-dsa key with ecdsa key details",
+			hashedrekord: request(der65, sign(priv65, digest[:]), v1.PublicKeyDetails_PKIX_ECDSA_P256_SHA_256, digest[:]),
+			expectErr:    fmt.Errorf("do not match"),
+			expectReason: validation.ReasonUnsupportedAlgorithm,
+		},
+		{
+			name:         "sha-256 digest",
+			hashedrekord: request(der65, sign(priv65, sha256Digest[:]), v1.PublicKeyDetails_ML_DSA_65, sha256Digest[:]),
+			expectErr:    fmt.Errorf("ML-DSA entries require a SHA-512 digest"),
+			expectReason: validation.ReasonUnsupportedAlgorithm,
+		},
+		{
+			name:         "invalid signature",
+			hashedrekord: request(der65, sign(priv65, otherDigest[:]), v1.PublicKeyDetails_ML_DSA_65, digest[:]),
+			expectErr:    fmt.Errorf("verifying signature"),
+			expectReason: validation.ReasonSignatureInvalid,
+		},
+	}
+	algReg, err := signature.NewAlgorithmRegistryConfig([]v1.PublicKeyDetails{v1.PublicKeyDetails_PKIX_ECDSA_P256_SHA_256})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			allowedMLDSA := test.allowedMLDSA
+			if allowedMLDSA == nil {
+				allowedMLDSA = []v1.PublicKeyDetails{v1.PublicKeyDetails_ML_DSA_65, v1.PublicKeyDetails_ML_DSA_87}
+			}
+			entry, gotErr := ToLogEntry(test.hashedrekord, algReg, WithMLDSA(allowedMLDSA...))
+			if test.expectErr == nil {
+				if assert.NoError(t, gotErr) {
+					hr := entry.GetSpec().GetHashedRekordV002()
+					assert.Equal(t, v1.HashAlgorithm_SHA2_512, hr.GetData().GetAlgorithm())
+					assert.Equal(t, test.hashedrekord.Digest, hr.GetData().GetDigest())
+					assert.Equal(t, test.hashedrekord.Signature.Verifier.KeyDetails, hr.GetSignature().GetVerifier().GetKeyDetails())
+				}
+			} else {
+				assert.ErrorContains(t, gotErr, test.expectErr.Error())
+				assert.Equal(t, test.expectReason, validation.ReasonOf(gotErr))
+			}
+		})
+	}
+
+	t.Run("nil registry", func(t *testing.T) {
+		_, err := ToLogEntry(request(der65, sign(priv65, digest[:]), v1.PublicKeyDetails_ML_DSA_65, digest[:]), nil, WithMLDSA(v1.PublicKeyDetails_ML_DSA_65))
+		assert.ErrorContains(t, err, "missing algorithm registry")
+	})
+	t.Run("non-ml-dsa algorithm", func(t *testing.T) {
+		_, err := ToLogEntry(request(der65, sign(priv65, digest[:]), v1.PublicKeyDetails_ML_DSA_65, digest[:]), algReg, WithMLDSA(v1.PublicKeyDetails_PKIX_ED25519))
+		assert.ErrorContains(t, err, "not an ML-DSA algorithm")
+	})
+}
@@ -0,0 +1,80 @@
+// Copyright 2025 The Sigstore Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Domain is the google.rpc.ErrorInfo domain for validation errors returned by Rekor.
+const Domain = "rekor.sigstore.dev"
+
+// Reason is a stable, machine-readable code describing why an entry request was rejected.
+type Reason string
+
+const (
+	// ReasonMissingField is returned when a required field of the request is empty.
+	ReasonMissingField Reason = "MISSING_FIELD"
+	// ReasonMissingVerifier is returned when the request has no public key or certificate.
+	ReasonMissingVerifier Reason = "MISSING_VERIFIER"
+	// ReasonInvalidVerifier is returned when a public key or certificate can't be parsed.
+	ReasonInvalidVerifier Reason = "INVALID_VERIFIER"
+	// ReasonUnsupportedAlgorithm is returned when the signing algorithm is not allowed by the log.
+	ReasonUnsupportedAlgorithm Reason = "UNSUPPORTED_ALGORITHM"
+	// ReasonSignatureInvalid is returned when a signature fails verification.
+	ReasonSignatureInvalid Reason = "SIGNATURE_INVALID"
+	// ReasonUnverifiedDSSESignature is returned when a DSSE envelope signature has no verifier that verifies it.
+	ReasonUnverifiedDSSESignature Reason = "UNVERIFIED_DSSE_SIGNATURE"
+	// ReasonUntrustedCertificate is returned when a certificate does not chain to the log's trusted roots.
+	ReasonUntrustedCertificate Reason = "UNTRUSTED_CERTIFICATE"
+)
+
+// Error is a request validation error with a stable reason code and the path
+// of the request field that caused it, relative to the entry type's request.
+type Error struct {
+	Reason Reason
+	Field  string
+	err    error
+}
+
+// Errorf returns a validation Error for the given reason and field, formatting the
+// underlying error as fmt.Errorf does.
+func Errorf(reason Reason, field, format string, a ...any) error {
+	return &Error{Reason: reason, Field: field, err: fmt.Errorf(format, a...)}
+}
+
+// Wrap returns a validation Error for the given reason and field wrapping err.
+func Wrap(reason Reason, field string, err error) error {
+	return &Error{Reason: reason, Field: field, err: err}
+}
+
+func (e *Error) Error() string {
+	return e.err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// ReasonOf returns the reason of the first validation Error in err's chain, or
+// the empty string if there is none.
+func ReasonOf(err error) Reason {
+	var verr *Error
+	if errors.As(err, &verr) {
+		return verr.Reason
+	}
+	return ""
+}
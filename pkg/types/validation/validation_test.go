@@ -0,0 +1,40 @@
+// Copyright 2025 The Sigstore Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestError(t *testing.T) {
+	err := Errorf(ReasonMissingField, "digest", "missing digest")
+	assert.EqualError(t, err, "missing digest")
+	assert.Equal(t, ReasonMissingField, ReasonOf(err))
+
+	wrapped := fmt.Errorf("validating: %w", Wrap(ReasonSignatureInvalid, "signature.content", io.ErrUnexpectedEOF))
+	assert.EqualError(t, wrapped, "validating: unexpected EOF")
+	assert.Equal(t, ReasonSignatureInvalid, ReasonOf(wrapped))
+	assert.ErrorIs(t, wrapped, io.ErrUnexpectedEOF)
+	var verr *Error
+	assert.ErrorAs(t, wrapped, &verr)
+	assert.Equal(t, "signature.content", verr.Field)
+
+	assert.Equal(t, Reason(""), ReasonOf(errors.New("other")))
+}
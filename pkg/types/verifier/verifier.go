@@ -37,5 +37,32 @@ func Validate(v *pb.Verifier) error {
 			return fmt.Errorf("missing X.509 certificate raw bytes")
 		}
 	}
+	if len(v.GetIntermediates()) > 0 && x509Cert == nil {
+		return fmt.Errorf("intermediate certificates require an X.509 certificate")
+	}
+	for _, intermediate := range v.GetIntermediates() {
+		if len(intermediate.GetRawBytes()) == 0 {
+			return fmt.Errorf("missing intermediate certificate raw bytes")
+		}
+	}
 	return nil
 }
+
+// Intermediates returns the DER-encoded intermediate certificates of the verifier.
+func Intermediates(v *pb.Verifier) [][]byte {
+	var ders [][]byte
+	for _, intermediate := range v.GetIntermediates() {
+		ders = append(ders, intermediate.GetRawBytes())
+	}
+	return ders
+}
+
+// WithoutIntermediates returns the verifier to persist in a log entry, which omits
+// intermediate certificates so that an entry's canonicalized body can be reconstructed
+// from the signing certificate alone.
+func WithoutIntermediates(v *pb.Verifier) *pb.Verifier {
+	if len(v.GetIntermediates()) == 0 {
+		return v
+	}
+	return &pb.Verifier{Verifier: v.Verifier, KeyDetails: v.KeyDetails}
+}
@@ -72,6 +72,41 @@ func TestValidate(t *testing.T) {
 			},
 			expectErr: fmt.Errorf("missing X.509 certificate raw bytes"),
 		},
+		{
+			name: "x.509 verifier with intermediates",
+			verifier: &pb.Verifier{
+				Verifier: &pb.Verifier_X509Certificate{
+					X509Certificate: &v1.X509Certificate{
+						RawBytes: []byte("abcd"),
+					},
+				},
+				Intermediates: []*v1.X509Certificate{{RawBytes: []byte("efgh")}},
+			},
+		},
+		{
+			name: "public key with intermediates",
+			verifier: &pb.Verifier{
+				Verifier: &pb.Verifier_PublicKey{
+					PublicKey: &pb.PublicKey{
+						RawBytes: []byte("abcd"),
+					},
+				},
+				Intermediates: []*v1.X509Certificate{{RawBytes: []byte("efgh")}},
+			},
+			expectErr: fmt.Errorf("intermediate certificates require an X.509 certificate"),
+		},
+		{
+			name: "intermediate missing content",
+			verifier: &pb.Verifier{
+				Verifier: &pb.Verifier_X509Certificate{
+					X509Certificate: &v1.X509Certificate{
+						RawBytes: []byte("abcd"),
+					},
+				},
+				Intermediates: []*v1.X509Certificate{{}},
+			},
+			expectErr: fmt.Errorf("missing intermediate certificate raw bytes"),
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -23,7 +23,9 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/sigstore/rekor-tiles/v2/internal/mldsa"
 	"github.com/sigstore/rekor-tiles/v2/pkg/verifier/identity"
+	fulcio "github.com/sigstore/sigstore-go/pkg/fulcio/certificate"
 	"github.com/sigstore/sigstore/pkg/cryptoutils"
 )
 
@@ -44,6 +46,15 @@ func NewVerifier(r io.Reader) (*Certificate, error) {
 	if err != nil {
 		return nil, fmt.Errorf("parsing certificate: %v", err)
 	}
+	// Use the same ML-DSA key type as public key verifiers, whether or not the
+	// standard library supports ML-DSA
+	key, err := mldsa.ParsePKIXPublicKey(cert.RawSubjectPublicKeyInfo)
+	switch {
+	case err == nil:
+		cert.PublicKey = key
+	case !errors.Is(err, mldsa.ErrNotMLDSA):
+		return nil, fmt.Errorf("parsing certificate public key: %v", err)
+	}
 	return &Certificate{cert: cert}, nil
 }
 
@@ -59,11 +70,28 @@ func (c Certificate) PublicKey() crypto.PublicKey {
 	return c.cert.PublicKey
 }
 
+// Identity returns the certificate along with the signer's identity from its subject
+// alternative names and Fulcio extensions. If the Fulcio extensions are malformed, they
+// are left empty rather than failing, since the rest of the identity is still valid.
 func (c Certificate) Identity() (identity.Identity, error) {
+	extensions, err := fulcio.ParseExtensions(c.cert.Extensions)
+	if err != nil {
+		extensions = fulcio.Extensions{}
+	}
+	var uris []string
+	for _, uri := range c.cert.URIs {
+		uris = append(uris, uri.String())
+	}
+	// Certificates without an OtherName SAN return an error, which is ignored
+	otherName, _ := cryptoutils.UnmarshalOtherNameSAN(c.cert.Extensions)
 	digest := sha256.Sum256(c.cert.Raw)
 	return identity.Identity{
-		Crypto:      c.cert,
-		Raw:         c.cert.Raw,
-		Fingerprint: hex.EncodeToString(digest[:]),
+		Crypto:         c.cert,
+		Raw:            c.cert.Raw,
+		Fingerprint:    hex.EncodeToString(digest[:]),
+		EmailAddresses: c.cert.EmailAddresses,
+		URIs:           uris,
+		OtherName:      otherName,
+		Extensions:     extensions,
 	}, nil
 }
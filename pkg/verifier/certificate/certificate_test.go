@@ -22,14 +22,20 @@ import (
 	"crypto/sha256"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/asn1"
 	"encoding/hex"
 	"encoding/pem"
 	"io"
 	"math/big"
+	"net/url"
 	"reflect"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/sigstore/rekor-tiles/v2/pkg/verifier/identity"
+	fulcio "github.com/sigstore/sigstore-go/pkg/fulcio/certificate"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
 )
 
 func TestNewVerifier(t *testing.T) {
@@ -218,3 +224,133 @@ func generateTestCertificate() (*x509.Certificate, []byte, error) {
 
 	return cert, derBytes, nil
 }
+
+func TestCertificate_IdentityFulcio(t *testing.T) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	derString := func(s string) []byte {
+		der, err := asn1.MarshalWithParams(s, "utf8")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return der
+	}
+	newCert := func(template *x509.Certificate) *Certificate {
+		template.SerialNumber = big.NewInt(1)
+		template.NotBefore = time.Now().Add(-time.Hour)
+		template.NotAfter = time.Now().Add(time.Hour)
+		der, err := x509.CreateCertificate(rand.Reader, template, template, &priv.PublicKey, priv)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &Certificate{cert: cert}
+	}
+	workflowURI, err := url.Parse("https://github.com/sigstore/rekor-tiles/.github/workflows/release.yml@refs/tags/v2.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherNameSAN, err := cryptoutils.MarshalOtherNameSAN("user!example.com", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		template     *x509.Certificate
+		wantIdentity identity.Identity
+	}{
+		{
+			name: "email",
+			template: &x509.Certificate{
+				EmailAddresses: []string{"user@example.com"},
+				ExtraExtensions: []pkix.Extension{
+					{Id: fulcio.OIDIssuerV2, Value: derString("https://accounts.google.com")},
+				},
+			},
+			wantIdentity: identity.Identity{
+				EmailAddresses: []string{"user@example.com"},
+				Extensions:     fulcio.Extensions{Issuer: "https://accounts.google.com"},
+			},
+		},
+		{
+			name: "github workflow",
+			template: &x509.Certificate{
+				URIs: []*url.URL{workflowURI},
+				ExtraExtensions: []pkix.Extension{
+					{Id: fulcio.OIDIssuer, Value: []byte("https://token.actions.githubusercontent.com")},
+					{Id: fulcio.OIDGitHubWorkflowTrigger, Value: []byte("push")},
+					{Id: fulcio.OIDIssuerV2, Value: derString("https://token.actions.githubusercontent.com")},
+					{Id: fulcio.OIDSourceRepositoryURI, Value: derString("https://github.com/sigstore/rekor-tiles")},
+					{Id: fulcio.OIDSourceRepositoryRef, Value: derString("refs/tags/v2.0.0")},
+					{Id: fulcio.OIDRunnerEnvironment, Value: derString("github-hosted")},
+				},
+			},
+			wantIdentity: identity.Identity{
+				URIs: []string{workflowURI.String()},
+				Extensions: fulcio.Extensions{
+					Issuer:                "https://token.actions.githubusercontent.com",
+					GithubWorkflowTrigger: "push",
+					SourceRepositoryURI:   "https://github.com/sigstore/rekor-tiles",
+					SourceRepositoryRef:   "refs/tags/v2.0.0",
+					RunnerEnvironment:     "github-hosted",
+				},
+			},
+		},
+		{
+			name: "username",
+			template: &x509.Certificate{
+				ExtraExtensions: []pkix.Extension{*otherNameSAN},
+			},
+			wantIdentity: identity.Identity{
+				OtherName: "user!example.com",
+			},
+		},
+		{
+			name:     "no fulcio extensions",
+			template: &x509.Certificate{},
+		},
+		{
+			name: "malformed extension",
+			template: &x509.Certificate{
+				EmailAddresses: []string{"user@example.com"},
+				ExtraExtensions: []pkix.Extension{
+					{Id: fulcio.OIDIssuer, Value: []byte("https://accounts.google.com")},
+					{Id: fulcio.OIDIssuerV2, Value: []byte("https://accounts.google.com")},
+				},
+			},
+			wantIdentity: identity.Identity{
+				EmailAddresses: []string{"user@example.com"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newCert(tt.template)
+			id, err := c.Identity()
+			if err != nil {
+				t.Fatalf("Identity() returned unexpected error: %v", err)
+			}
+			if id.Fingerprint == "" {
+				t.Error("Identity Fingerprint is empty")
+			}
+			if !reflect.DeepEqual(id.EmailAddresses, tt.wantIdentity.EmailAddresses) {
+				t.Errorf("Identity EmailAddresses mismatch. Got: %v, Want: %v", id.EmailAddresses, tt.wantIdentity.EmailAddresses)
+			}
+			if !reflect.DeepEqual(id.URIs, tt.wantIdentity.URIs) {
+				t.Errorf("Identity URIs mismatch. Got: %v, Want: %v", id.URIs, tt.wantIdentity.URIs)
+			}
+			if id.OtherName != tt.wantIdentity.OtherName {
+				t.Errorf("Identity OtherName mismatch. Got: %s, Want: %s", id.OtherName, tt.wantIdentity.OtherName)
+			}
+			if id.Extensions != tt.wantIdentity.Extensions {
+				t.Errorf("Identity Extensions mismatch. Got: %+v, Want: %+v", id.Extensions, tt.wantIdentity.Extensions)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 The Sigstore Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certificate
+
+import (
+	"bytes"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+)
+
+// TrustBundle is a set of trusted root certificates, along with intermediate
+// certificates that chain to them, such as Fulcio's certificate chain.
+type TrustBundle struct {
+	roots         *x509.CertPool
+	intermediates *x509.CertPool
+}
+
+// NewTrustBundle returns a bundle trusting the given roots. Intermediates are
+// used to build chains to the roots, but are not trusted themselves.
+func NewTrustBundle(roots, intermediates []*x509.Certificate) (*TrustBundle, error) {
+	if len(roots) == 0 {
+		return nil, errors.New("trust bundle must contain at least one root certificate")
+	}
+	b := &TrustBundle{roots: x509.NewCertPool(), intermediates: x509.NewCertPool()}
+	for _, root := range roots {
+		b.roots.AddCert(root)
+	}
+	for _, intermediate := range intermediates {
+		b.intermediates.AddCert(intermediate)
+	}
+	return b, nil
+}
+
+// LoadTrustBundle reads a trust bundle from a file of PEM-encoded certificates.
+// Self-signed certificates are trusted as roots, and all others are intermediates.
+func LoadTrustBundle(path string) (*TrustBundle, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading trust bundle: %w", err)
+	}
+	certs, err := cryptoutils.UnmarshalCertificatesFromPEM(contents)
+	if err != nil {
+		return nil, fmt.Errorf("parsing trust bundle: %w", err)
+	}
+	var roots, intermediates []*x509.Certificate
+	for _, cert := range certs {
+		if isSelfSigned(cert) {
+			roots = append(roots, cert)
+		} else {
+			intermediates = append(intermediates, cert)
+		}
+	}
+	return NewTrustBundle(roots, intermediates)
+}
+
+func isSelfSigned(cert *x509.Certificate) bool {
+	return bytes.Equal(cert.RawSubject, cert.RawIssuer) && cert.CheckSignatureFrom(cert) == nil
+}
+
+// VerifyChain verifies that the certificate chains to one of the bundle's roots,
+// using the bundle's intermediates along with DER-encoded intermediates supplied with
+// the certificate. Since signing certificates are often short-lived, the chain is
+// verified at the start of the certificate's validity period rather than the current
+// time. The certificate must be valid for code signing.
+func (c Certificate) VerifyChain(bundle *TrustBundle, intermediates [][]byte) error {
+	pool := bundle.intermediates.Clone()
+	for i, der := range intermediates {
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return fmt.Errorf("parsing intermediate certificate %d: %w", i, err)
+		}
+		pool.AddCert(cert)
+	}
+	if _, err := c.cert.Verify(x509.VerifyOptions{
+		Roots:         bundle.roots,
+		Intermediates: pool,
+		CurrentTime:   c.cert.NotBefore,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	}); err != nil {
+		return fmt.Errorf("verifying certificate chain: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+// Copyright 2025 The Sigstore Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certificate
+
+import (
+	"crypto/x509"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sigstore/rekor-tiles/v2/internal/testutil"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyChain(t *testing.T) {
+	root := testutil.NewCA(t, "root", nil)
+	intermediate := testutil.NewCA(t, "intermediate", root)
+	otherRoot := testutil.NewCA(t, "other root", nil)
+	now := time.Now()
+	leaf, _ := intermediate.IssueLeaf(t, now.Add(-time.Minute), now.Add(10*time.Minute), x509.ExtKeyUsageCodeSigning)
+	expiredLeaf, _ := intermediate.IssueLeaf(t, now.Add(-time.Hour), now.Add(-50*time.Minute), x509.ExtKeyUsageCodeSigning)
+	serverLeaf, _ := intermediate.IssueLeaf(t, now.Add(-time.Minute), now.Add(10*time.Minute), x509.ExtKeyUsageServerAuth)
+	rootLeaf, _ := root.IssueLeaf(t, now.Add(-time.Minute), now.Add(10*time.Minute), x509.ExtKeyUsageCodeSigning)
+
+	rootsOnly, err := NewTrustBundle([]*x509.Certificate{root.Cert}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	withIntermediate, err := NewTrustBundle([]*x509.Certificate{root.Cert}, []*x509.Certificate{intermediate.Cert})
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := NewTrustBundle([]*x509.Certificate{otherRoot.Cert}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		leaf          *x509.Certificate
+		bundle        *TrustBundle
+		intermediates [][]byte
+		wantErr       string
+	}{
+		{
+			name:   "issued by root",
+			leaf:   rootLeaf,
+			bundle: rootsOnly,
+		},
+		{
+			name:   "intermediate in bundle",
+			leaf:   leaf,
+			bundle: withIntermediate,
+		},
+		{
+			name:          "intermediate supplied with certificate",
+			leaf:          leaf,
+			bundle:        rootsOnly,
+			intermediates: [][]byte{intermediate.Cert.Raw},
+		},
+		{
+			name:   "expired certificate verified at issuance",
+			leaf:   expiredLeaf,
+			bundle: withIntermediate,
+		},
+		{
+			name:    "missing intermediate",
+			leaf:    leaf,
+			bundle:  rootsOnly,
+			wantErr: "verifying certificate chain",
+		},
+		{
+			name:          "untrusted root",
+			leaf:          leaf,
+			bundle:        other,
+			intermediates: [][]byte{intermediate.Cert.Raw},
+			wantErr:       "verifying certificate chain",
+		},
+		{
+			name:    "not for code signing",
+			leaf:    serverLeaf,
+			bundle:  withIntermediate,
+			wantErr: "verifying certificate chain",
+		},
+		{
+			name:          "invalid intermediate",
+			leaf:          leaf,
+			bundle:        rootsOnly,
+			intermediates: [][]byte{[]byte("not a certificate")},
+			wantErr:       "parsing intermediate certificate 0",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := Certificate{cert: test.leaf}.VerifyChain(test.bundle, test.intermediates)
+			if test.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadTrustBundle(t *testing.T) {
+	root := testutil.NewCA(t, "root", nil)
+	intermediate := testutil.NewCA(t, "intermediate", root)
+	now := time.Now()
+	leaf, _ := intermediate.IssueLeaf(t, now.Add(-time.Minute), now.Add(10*time.Minute), x509.ExtKeyUsageCodeSigning)
+
+	dir := t.TempDir()
+	chainPEM, err := cryptoutils.MarshalCertificatesToPEM([]*x509.Certificate{intermediate.Cert, root.Cert})
+	if err != nil {
+		t.Fatal(err)
+	}
+	chainPath := filepath.Join(dir, "chain.pem")
+	if err := os.WriteFile(chainPath, chainPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	bundle, err := LoadTrustBundle(chainPath)
+	if assert.NoError(t, err) {
+		assert.NoError(t, Certificate{cert: leaf}.VerifyChain(bundle, nil))
+	}
+
+	// A bundle of only intermediates has no trusted roots
+	intermediatePEM, err := cryptoutils.MarshalCertificateToPEM(intermediate.Cert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	intermediatePath := filepath.Join(dir, "intermediate.pem")
+	if err := os.WriteFile(intermediatePath, intermediatePEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	_, err = LoadTrustBundle(intermediatePath)
+	assert.ErrorContains(t, err, "at least one root certificate")
+
+	_, err = LoadTrustBundle(filepath.Join(dir, "missing.pem"))
+	assert.ErrorContains(t, err, "reading trust bundle")
+}
@@ -16,6 +16,10 @@
 
 package identity
 
+import (
+	fulcio "github.com/sigstore/sigstore-go/pkg/fulcio/certificate"
+)
+
 type Identity struct {
 	// Types include:
 	// - *rsa.PublicKey
@@ -31,4 +35,16 @@ type Identity struct {
 	// - SHA-256 digest of the PKIX ASN.1 DER-encoded public key
 	// - SHA-256 digest of the ASN.1 DER-encoded certificate
 	Fingerprint string
+	// Subject alternative names of a certificate, which identify the signer of a
+	// Fulcio certificate. Empty for public keys.
+	EmailAddresses []string
+	URIs           []string
+	// OtherName is the UTF-8 OtherName subject alternative name (OID 1.3.6.1.4.1.57264.1.7),
+	// which Fulcio uses for usernames
+	OtherName string
+	// Extensions contains the values of a Fulcio certificate's extensions
+	// (OIDs 1.3.6.1.4.1.57264.1.*), such as the OIDC issuer and the details of the
+	// CI workflow that requested the certificate. Empty for public keys and
+	// certificates without Fulcio extensions.
+	Extensions fulcio.Extensions
 }
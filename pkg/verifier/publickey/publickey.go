@@ -19,10 +19,12 @@ import (
 	"crypto/sha256"
 	"crypto/x509"
 	"encoding/hex"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"io"
 
+	"github.com/sigstore/rekor-tiles/v2/internal/mldsa"
 	"github.com/sigstore/rekor-tiles/v2/pkg/verifier/identity"
 	"github.com/sigstore/sigstore/pkg/cryptoutils"
 )
@@ -41,7 +43,12 @@ func NewVerifier(r io.Reader) (*PublicKey, error) {
 		return nil, err
 	}
 
-	key, err := x509.ParsePKIXPublicKey(derVerifier)
+	// ML-DSA keys are parsed first, so that they have the same type regardless of
+	// whether the standard library supports ML-DSA
+	key, err := mldsa.ParsePKIXPublicKey(derVerifier)
+	if errors.Is(err, mldsa.ErrNotMLDSA) {
+		key, err = x509.ParsePKIXPublicKey(derVerifier)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("parsing public key: %v", err)
 	}
@@ -49,11 +56,11 @@ func NewVerifier(r io.Reader) (*PublicKey, error) {
 }
 
 func (k PublicKey) String() string {
-	encoded, err := cryptoutils.MarshalPublicKeyToPEM(k.key)
+	der, err := marshalPublicKey(k.key)
 	if err != nil {
 		return ""
 	}
-	return string(encoded)
+	return string(pem.EncodeToMemory(&pem.Block{Type: string(cryptoutils.PublicKeyPEMType), Bytes: der}))
 
 }
 
@@ -62,7 +69,7 @@ func (k PublicKey) PublicKey() crypto.PublicKey {
 }
 
 func (k PublicKey) Identity() (identity.Identity, error) {
-	pkixKey, err := cryptoutils.MarshalPublicKeyToDER(k.key)
+	pkixKey, err := marshalPublicKey(k.key)
 	if err != nil {
 		return identity.Identity{}, err
 	}
@@ -73,3 +80,12 @@ func (k PublicKey) Identity() (identity.Identity, error) {
 		Fingerprint: hex.EncodeToString(digest[:]),
 	}, nil
 }
+
+// marshalPublicKey converts a public key to PKIX, ASN.1 DER form, including ML-DSA
+// keys, which cryptoutils does not support.
+func marshalPublicKey(key crypto.PublicKey) ([]byte, error) {
+	if _, ok := mldsa.KeyDetails(key); ok {
+		return mldsa.MarshalPKIXPublicKey(key)
+	}
+	return cryptoutils.MarshalPublicKeyToDER(key)
+}
@@ -29,6 +29,9 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/cloudflare/circl/sign/mldsa/mldsa65"
+	"github.com/sigstore/rekor-tiles/v2/internal/mldsa"
 )
 
 func TestNewVerifier(t *testing.T) {
@@ -179,6 +182,47 @@ func TestPublicKey_Identity(t *testing.T) {
 
 }
 
+func TestMLDSA(t *testing.T) {
+	pub, _, err := mldsa65.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := mldsa.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	verifier, err := NewVerifier(bytes.NewReader(der))
+	if err != nil {
+		t.Fatalf("NewVerifier() returned unexpected error: %v", err)
+	}
+	if !pub.Equal(verifier.PublicKey()) {
+		t.Errorf("NewVerifier() key = %T, want %T", verifier.PublicKey(), pub)
+	}
+
+	block, _ := pem.Decode([]byte(verifier.String()))
+	if block == nil || !bytes.Equal(block.Bytes, der) {
+		t.Errorf("String() did not return the PEM-encoded public key")
+	}
+
+	id, err := verifier.Identity()
+	if err != nil {
+		t.Fatalf("Identity() returned unexpected error: %v", err)
+	}
+	if !bytes.Equal(id.Raw, der) {
+		t.Errorf("Identity Raw field mismatch. Got %d bytes, want %d bytes.", len(id.Raw), len(der))
+	}
+	expectedDigest := sha256.Sum256(der)
+	if id.Fingerprint != hex.EncodeToString(expectedDigest[:]) {
+		t.Errorf("Identity Fingerprint mismatch. Got: %s", id.Fingerprint)
+	}
+
+	_, err = NewVerifier(bytes.NewReader(der[:len(der)-1]))
+	if err == nil || !strings.Contains(err.Error(), "parsing public key") {
+		t.Errorf("NewVerifier() error = %v, want error parsing truncated ML-DSA key", err)
+	}
+}
+
 // generateTestPublicKey creates an ECDSA key pair and returns the public key
 // and its DER-encoded PKIX representation.
 func generateTestPublicKey() (crypto.PublicKey, []byte, error) {
@@ -0,0 +1,105 @@
+//
+// Copyright 2025 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/cyberphone/json-canonicalization/go/src/webpki.org/jsoncanonicalizer"
+	v1 "github.com/sigstore/protobuf-specs/gen/pb-go/common/v1"
+	pbdsse "github.com/sigstore/protobuf-specs/gen/pb-go/dsse"
+	pbs "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
+	"github.com/sigstore/rekor-tiles/v2/internal/algorithmregistry"
+	pb "github.com/sigstore/rekor-tiles/v2/pkg/generated/protobuf"
+	"github.com/sigstore/rekor-tiles/v2/pkg/types/dsse"
+	"github.com/sigstore/rekor-tiles/v2/pkg/types/hashedrekord"
+	sumdb_note "golang.org/x/mod/sumdb/note"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// ErrBodyMismatch is returned when a reconstructed canonicalized body does not match a log entry.
+var ErrBodyMismatch = errors.New("canonicalized body does not match log entry")
+
+// HashedRekordCanonicalizedBody returns the canonicalized body that the log creates
+// for a hashedrekord entry of the given kind and version with the artifact digest and
+// signature. The signature is verified, allowing only the signature's key algorithm.
+func HashedRekordCanonicalizedBody(digest []byte, sig *pb.Signature, kv *pbs.KindVersion) ([]byte, error) {
+	registry, err := algorithmregistry.NewRegistry([]v1.PublicKeyDetails{sig.GetVerifier().GetKeyDetails()})
+	if err != nil {
+		return nil, err
+	}
+	entry, err := hashedrekord.ToLogEntry(&pb.HashedRekordRequestV002{Digest: digest, Signature: sig}, registry.Config(), hashedrekord.WithMLDSA(registry.MLDSA()...))
+	if err != nil {
+		return nil, err
+	}
+	return canonicalize(entry, kv)
+}
+
+// DSSECanonicalizedBody returns the canonicalized body that the log creates for a
+// dsse entry of the given kind and version with the envelope and verifiers. The
+// envelope signatures are verified, allowing only the verifiers' key algorithms.
+func DSSECanonicalizedBody(envelope *pbdsse.Envelope, verifiers []*pb.Verifier, kv *pbs.KindVersion) ([]byte, error) {
+	var keyDetails []v1.PublicKeyDetails
+	for _, v := range verifiers {
+		keyDetails = append(keyDetails, v.GetKeyDetails())
+	}
+	registry, err := algorithmregistry.NewRegistry(keyDetails)
+	if err != nil {
+		return nil, err
+	}
+	entry, err := dsse.ToLogEntry(&pb.DSSERequestV002{Envelope: envelope, Verifiers: verifiers}, registry.Config(), dsse.WithMLDSA(registry.MLDSA()...))
+	if err != nil {
+		return nil, err
+	}
+	return canonicalize(entry, kv)
+}
+
+// canonicalize serializes the entry the same way as the log, after checking that
+// the entry is of the expected kind and version.
+func canonicalize(entry *pb.Entry, kv *pbs.KindVersion) ([]byte, error) {
+	if entry.GetKind() != kv.GetKind() || entry.GetApiVersion() != kv.GetVersion() {
+		return nil, fmt.Errorf("unsupported kind and version %s/%s, expected %s/%s", kv.GetKind(), kv.GetVersion(), entry.GetKind(), entry.GetApiVersion())
+	}
+	serialized, err := protojson.Marshal(entry)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling entry: %w", err)
+	}
+	return jsoncanonicalizer.Transform(serialized)
+}
+
+// VerifyCanonicalizedBody verifies that a reconstructed canonicalized body matches
+// the entry's canonicalized body, if present, and is the leaf proven by the entry's
+// inclusion proof against the signed checkpoint.
+func VerifyCanonicalizedBody(entry *pbs.TransparencyLogEntry, body []byte, verifier sumdb_note.Verifier) error { //nolint: revive
+	if len(entry.GetCanonicalizedBody()) > 0 && !bytes.Equal(entry.GetCanonicalizedBody(), body) {
+		return ErrBodyMismatch
+	}
+	cp, err := VerifyCheckpoint(entry.GetInclusionProof().GetCheckpoint().GetEnvelope(), verifier)
+	if err != nil {
+		return err
+	}
+	withBody := &pbs.TransparencyLogEntry{
+		LogIndex:          entry.GetLogIndex(),
+		CanonicalizedBody: body,
+		InclusionProof:    entry.GetInclusionProof(),
+	}
+	if err := VerifyInclusionProof(withBody, cp); err != nil {
+		return fmt.Errorf("%w: %w", ErrBodyMismatch, err)
+	}
+	return nil
+}
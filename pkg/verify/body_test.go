@@ -0,0 +1,135 @@
+//
+// Copyright 2025 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha512"
+	"crypto/x509"
+	"testing"
+
+	"github.com/secure-systems-lab/go-securesystemslib/dsse"
+	v1 "github.com/sigstore/protobuf-specs/gen/pb-go/common/v1"
+	pbdsse "github.com/sigstore/protobuf-specs/gen/pb-go/dsse"
+	pbs "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
+	pb "github.com/sigstore/rekor-tiles/v2/pkg/generated/protobuf"
+	rekornote "github.com/sigstore/rekor-tiles/v2/pkg/note"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/stretchr/testify/assert"
+)
+
+// goldenHashedRekordBody is the canonicalized body of a hashedrekord entry for the
+// artifact "artifact" signed with Ed25519ph by the key with seed 0x01...01. It must
+// only change if the log changes how entries are serialized.
+const goldenHashedRekordBody = `{"apiVersion":"0.0.2","kind":"hashedrekord","spec":{"hashedRekordV002":{"data":{"algorithm":"SHA2_512","digest":"FGl0QHAcOIX3yNX6pZ8za0ccqGMyA07/DT/dwC3JsYuDVuhA21SCPI/S8svQkGlpzxMs+Lucc9x2m0/9gXvSPQ=="},"signature":{"content":"RYC47/pOQh+NKWuPhBOlxxAtqogg1JryXVcLR2IbtpYt1XiKlD3rZlFi3Ibphur1noFNfMI8E8UJTjTdimCFDQ==","verifier":{"keyDetails":"PKIX_ED25519_PH","publicKey":{"rawBytes":"MCowBQYDK2VwAyEAiojj3XQJ8ZX9UtstPLpdcspnCb8dlBIb83SIAbQPb1w="}}}}}}`
+
+var (
+	hashedRekordKindVersion = &pbs.KindVersion{Kind: "hashedrekord", Version: "0.0.2"}
+	dsseKindVersion         = &pbs.KindVersion{Kind: "dsse", Version: "0.0.2"}
+)
+
+func testEd25519Key(t *testing.T) (ed25519.PrivateKey, *pb.PublicKey) {
+	priv := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{1}, ed25519.SeedSize))
+	der, err := x509.MarshalPKIXPublicKey(priv.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return priv, &pb.PublicKey{RawBytes: der}
+}
+
+func TestHashedRekordCanonicalizedBody(t *testing.T) {
+	priv, pubKey := testEd25519Key(t)
+	signer, err := signature.LoadED25519phSigner(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := signer.SignMessage(bytes.NewReader([]byte("artifact")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := sha512.Sum512([]byte("artifact"))
+	sig := &pb.Signature{
+		Content: content,
+		Verifier: &pb.Verifier{
+			Verifier:   &pb.Verifier_PublicKey{PublicKey: pubKey},
+			KeyDetails: v1.PublicKeyDetails_PKIX_ED25519_PH,
+		},
+	}
+
+	body, err := HashedRekordCanonicalizedBody(digest[:], sig, hashedRekordKindVersion)
+	assert.NoError(t, err)
+	assert.Equal(t, goldenHashedRekordBody, string(body))
+
+	_, err = HashedRekordCanonicalizedBody(digest[:], sig, dsseKindVersion)
+	assert.ErrorContains(t, err, "unsupported kind and version")
+	_, err = HashedRekordCanonicalizedBody(digest[:], sig, &pbs.KindVersion{Kind: "hashedrekord", Version: "0.0.1"})
+	assert.ErrorContains(t, err, "unsupported kind and version")
+	otherDigest := sha512.Sum512([]byte("other artifact"))
+	_, err = HashedRekordCanonicalizedBody(otherDigest[:], sig, hashedRekordKindVersion)
+	assert.Error(t, err, "signature should not verify")
+}
+
+func TestDSSECanonicalizedBody(t *testing.T) {
+	priv, pubKey := testEd25519Key(t)
+	signer, err := signature.LoadED25519Signer(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	payloadType, payload := "application/vnd.in-toto+json", []byte(`{"_type":"https://in-toto.io/Statement/v1"}`)
+	sig, err := signer.SignMessage(bytes.NewReader(dsse.PAE(payloadType, payload)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	envelope := &pbdsse.Envelope{
+		Payload:     payload,
+		PayloadType: payloadType,
+		Signatures:  []*pbdsse.Signature{{Sig: sig}},
+	}
+	verifiers := []*pb.Verifier{{
+		Verifier:   &pb.Verifier_PublicKey{PublicKey: pubKey},
+		KeyDetails: v1.PublicKeyDetails_PKIX_ED25519,
+	}}
+
+	body, err := DSSECanonicalizedBody(envelope, verifiers, dsseKindVersion)
+	if assert.NoError(t, err) {
+		assert.Contains(t, string(body), `{"apiVersion":"0.0.2","kind":"dsse","spec":{"dsseV002":{`)
+	}
+	_, err = DSSECanonicalizedBody(envelope, verifiers, hashedRekordKindVersion)
+	assert.ErrorContains(t, err, "unsupported kind and version")
+	envelope.Payload = []byte("tampered")
+	_, err = DSSECanonicalizedBody(envelope, verifiers, dsseKindVersion)
+	assert.Error(t, err, "signature should not verify")
+}
+
+func TestVerifyCanonicalizedBody(t *testing.T) {
+	log := newTestLog(t, "rekor.localhost", v1.PublicKeyDetails_PKIX_ECDSA_P256_SHA_256, nil)
+	verifier, err := rekornote.NewNoteVerifier(log.origin, log.signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := []byte(goldenHashedRekordBody)
+
+	entry := log.entryWithBody(t, body)
+	assert.NoError(t, VerifyCanonicalizedBody(entry, body, verifier))
+
+	entry.CanonicalizedBody = nil
+	assert.NoError(t, VerifyCanonicalizedBody(entry, body, verifier), "body should be verified against the leaf")
+	assert.ErrorIs(t, VerifyCanonicalizedBody(entry, []byte("other"), verifier), ErrBodyMismatch)
+
+	entry.CanonicalizedBody = []byte("other")
+	assert.ErrorIs(t, VerifyCanonicalizedBody(entry, body, verifier), ErrBodyMismatch)
+}
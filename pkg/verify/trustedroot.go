@@ -0,0 +1,169 @@
+//
+// Copyright 2025 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"bytes"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	pbbundle "github.com/sigstore/protobuf-specs/gen/pb-go/bundle/v1"
+	pbs "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
+	pbtr "github.com/sigstore/protobuf-specs/gen/pb-go/trustroot/v1"
+	rekornote "github.com/sigstore/rekor-tiles/v2/pkg/note"
+	"github.com/sigstore/sigstore/pkg/signature"
+	sumdb_note "golang.org/x/mod/sumdb/note"
+)
+
+// ErrNoMatchingLog is returned when no transparency log in the trusted root signed a checkpoint.
+var ErrNoMatchingLog = errors.New("no matching transparency log in trusted root")
+
+// ErrNoObservedTime is returned when the log's key has a validity period but there is
+// no time to check it against. Rekor v2 entries have no integrated time, so callers
+// must pass WithObservedTime, for example with the time of a verified signed timestamp.
+var ErrNoObservedTime = errors.New("no observed time to check the log key's validity period")
+
+type trustedRootOptions struct {
+	observedTime  time.Time
+	witnessPolicy *WitnessPolicy
+	witnessOpts   []WitnessOption
+}
+
+// TrustedRootOption configures verification of log entries against a trusted root.
+type TrustedRootOption func(*trustedRootOptions)
+
+// WithObservedTime sets the time at which the log's key must have been valid, such
+// as a verified signed timestamp. Defaults to the entry's integrated time if set.
+// It is required for entries without an integrated time if the key has a validity period.
+func WithObservedTime(t time.Time) TrustedRootOption {
+	return func(o *trustedRootOptions) {
+		o.observedTime = t
+	}
+}
+
+// WithWitnessPolicy requires the checkpoint of each entry to be cosigned by a quorum
+// of witnesses as described by the policy.
+func WithWitnessPolicy(policy *WitnessPolicy, opts ...WitnessOption) TrustedRootOption {
+	return func(o *trustedRootOptions) {
+		o.witnessPolicy = policy
+		o.witnessOpts = opts
+	}
+}
+
+// NoteVerifierFromTrustedRoot finds the transparency log in the trusted root that
+// signed the checkpoint, matching the checkpoint's origin and signature key hash,
+// and returns the log and a note verifier for its key. If logID is not empty, the
+// log must also have that log ID or checkpoint key ID. The checkpoint signature is
+// not verified.
+func NoteVerifierFromTrustedRoot(trustedRoot *pbtr.TrustedRoot, unverifiedCp string, logID []byte) (*pbtr.TransparencyLogInstance, sumdb_note.Verifier, error) {
+	origin, _, _ := strings.Cut(unverifiedCp, "\n")
+	var sigs []sumdb_note.Signature
+	if _, err := sumdb_note.Open([]byte(unverifiedCp), sumdb_note.VerifierList()); err != nil {
+		var unverified *sumdb_note.UnverifiedNoteError
+		if !errors.As(err, &unverified) {
+			return nil, nil, fmt.Errorf("parsing checkpoint: %w", err)
+		}
+		sigs = unverified.Note.UnverifiedSigs
+	}
+	for _, tlog := range trustedRoot.GetTlogs() {
+		if len(logID) > 0 && !bytes.Equal(logID, tlog.GetLogId().GetKeyId()) && !bytes.Equal(logID, tlog.GetCheckpointKeyId().GetKeyId()) {
+			continue
+		}
+		pubKey, err := x509.ParsePKIXPublicKey(tlog.GetPublicKey().GetRawBytes())
+		if err != nil {
+			continue
+		}
+		keyID, _, err := rekornote.KeyHash(origin, pubKey)
+		if err != nil {
+			continue
+		}
+		for _, sig := range sigs {
+			if sig.Name != origin || sig.Hash != keyID {
+				continue
+			}
+			algDetails, err := signature.GetAlgorithmDetails(tlog.GetPublicKey().GetKeyDetails())
+			if err != nil {
+				return nil, nil, fmt.Errorf("getting key algorithm details for log %s: %w", tlog.GetBaseUrl(), err)
+			}
+			sigVerifier, err := signature.LoadVerifierFromAlgorithmDetails(pubKey, algDetails)
+			if err != nil {
+				return nil, nil, fmt.Errorf("loading verifier for log %s: %w", tlog.GetBaseUrl(), err)
+			}
+			verifier, err := rekornote.NewNoteVerifier(origin, sigVerifier)
+			if err != nil {
+				return nil, nil, fmt.Errorf("creating note verifier for log %s: %w", tlog.GetBaseUrl(), err)
+			}
+			return tlog, verifier, nil
+		}
+	}
+	return nil, nil, fmt.Errorf("%w: checkpoint origin %q", ErrNoMatchingLog, origin)
+}
+
+// VerifyLogEntryWithTrustedRoot verifies the log entry using the key of the matching
+// transparency log in the trusted root. This includes checking that the log's key
+// was valid at the observed time, verifying the signature on the entry's inclusion
+// proof checkpoint and verifying the entry inclusion proof.
+func VerifyLogEntryWithTrustedRoot(entry *pbs.TransparencyLogEntry, trustedRoot *pbtr.TrustedRoot, opts ...TrustedRootOption) error { //nolint: revive
+	o := &trustedRootOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	cp := entry.GetInclusionProof().GetCheckpoint().GetEnvelope()
+	if cp == "" {
+		return fmt.Errorf("entry has no inclusion proof checkpoint")
+	}
+	tlog, verifier, err := NoteVerifierFromTrustedRoot(trustedRoot, cp, entry.GetLogId().GetKeyId())
+	if err != nil {
+		return err
+	}
+	observed := o.observedTime
+	if observed.IsZero() && entry.GetIntegratedTime() > 0 {
+		observed = time.Unix(entry.GetIntegratedTime(), 0)
+	}
+	if validFor := tlog.GetPublicKey().GetValidFor(); validFor != nil {
+		if observed.IsZero() {
+			return fmt.Errorf("log %s: %w", tlog.GetBaseUrl(), ErrNoObservedTime)
+		}
+		if (validFor.GetStart() != nil && observed.Before(validFor.GetStart().AsTime())) ||
+			(validFor.GetEnd() != nil && observed.After(validFor.GetEnd().AsTime())) {
+			return fmt.Errorf("key for log %s was not valid at %s", tlog.GetBaseUrl(), observed.UTC().Format(time.RFC3339))
+		}
+	}
+	if o.witnessPolicy != nil {
+		_, err := VerifyLogEntryWithWitnessPolicy(entry, verifier, o.witnessPolicy, o.witnessOpts...)
+		return err
+	}
+	return VerifyLogEntry(entry, verifier)
+}
+
+// VerifyBundle verifies every transparency log entry in the bundle against the
+// trusted root with VerifyLogEntryWithTrustedRoot. It does not verify the bundle's
+// signature or that the entries match the bundle's content.
+func VerifyBundle(bundle *pbbundle.Bundle, trustedRoot *pbtr.TrustedRoot, opts ...TrustedRootOption) error { //nolint: revive
+	entries := bundle.GetVerificationMaterial().GetTlogEntries()
+	if len(entries) == 0 {
+		return fmt.Errorf("bundle has no transparency log entries")
+	}
+	for i, entry := range entries {
+		if err := VerifyLogEntryWithTrustedRoot(entry, trustedRoot, opts...); err != nil {
+			return fmt.Errorf("verifying transparency log entry %d: %w", i, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,252 @@
+//
+// Copyright 2025 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	pbbundle "github.com/sigstore/protobuf-specs/gen/pb-go/bundle/v1"
+	v1 "github.com/sigstore/protobuf-specs/gen/pb-go/common/v1"
+	pbs "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
+	pbtr "github.com/sigstore/protobuf-specs/gen/pb-go/trustroot/v1"
+	rekornote "github.com/sigstore/rekor-tiles/v2/pkg/note"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/stretchr/testify/assert"
+	f_log "github.com/transparency-dev/formats/log"
+	"github.com/transparency-dev/merkle/rfc6962"
+	note "golang.org/x/mod/sumdb/note"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type testLog struct {
+	origin   string
+	signer   signature.SignerVerifier
+	instance *pbtr.TransparencyLogInstance
+}
+
+func newTestLog(t *testing.T, origin string, keyDetails v1.PublicKeyDetails, validFor *v1.TimeRange) *testLog {
+	var priv crypto.Signer
+	var err error
+	switch keyDetails {
+	case v1.PublicKeyDetails_PKIX_ED25519:
+		_, priv, err = ed25519.GenerateKey(rand.Reader)
+	case v1.PublicKeyDetails_PKIX_RSA_PKCS1V15_2048_SHA256:
+		priv, err = rsa.GenerateKey(rand.Reader, 2048)
+	default:
+		priv, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	algDetails, err := signature.GetAlgorithmDetails(keyDetails)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sv, err := signature.LoadSignerVerifierFromAlgorithmDetails(priv, algDetails)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(priv.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, logID, err := rekornote.KeyHash(origin, priv.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testLog{
+		origin: origin,
+		signer: sv,
+		instance: &pbtr.TransparencyLogInstance{
+			BaseUrl:         "https://" + origin,
+			HashAlgorithm:   v1.HashAlgorithm_SHA2_256,
+			PublicKey:       &v1.PublicKey{RawBytes: der, KeyDetails: keyDetails, ValidFor: validFor},
+			LogId:           &v1.LogId{KeyId: logID},
+			CheckpointKeyId: &v1.LogId{KeyId: logID},
+		},
+	}
+}
+
+// entry returns an entry that is the only leaf in the log.
+func (l *testLog) entry(t *testing.T) *pbs.TransparencyLogEntry {
+	return l.entryWithBody(t, []byte(`{"kind":"test"}`))
+}
+
+// entryWithBody returns an entry with the body that is the only leaf in the log.
+func (l *testLog) entryWithBody(t *testing.T, body []byte) *pbs.TransparencyLogEntry {
+	noteSigner, err := rekornote.NewNoteSigner(context.Background(), l.origin, l.signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cp := f_log.Checkpoint{Origin: l.origin, Size: 1, Hash: rfc6962.DefaultHasher.HashLeaf(body)}.Marshal()
+	n, err := note.Sign(&note.Note{Text: string(cp)}, noteSigner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &pbs.TransparencyLogEntry{
+		LogIndex:          0,
+		LogId:             &v1.LogId{KeyId: l.instance.GetLogId().GetKeyId()},
+		CanonicalizedBody: body,
+		InclusionProof: &pbs.InclusionProof{
+			LogIndex:   0,
+			TreeSize:   1,
+			RootHash:   rfc6962.DefaultHasher.HashLeaf(body),
+			Checkpoint: &pbs.Checkpoint{Envelope: string(n)},
+		},
+	}
+}
+
+func TestVerifyLogEntryWithTrustedRoot(t *testing.T) {
+	now := time.Now()
+	expired := &v1.TimeRange{Start: timestamppb.New(now.Add(-48 * time.Hour)), End: timestamppb.New(now.Add(-24 * time.Hour))}
+	ecdsaLog := newTestLog(t, "ecdsa.rekor.localhost", v1.PublicKeyDetails_PKIX_ECDSA_P256_SHA_256, nil)
+	ed25519Log := newTestLog(t, "ed25519.rekor.localhost", v1.PublicKeyDetails_PKIX_ED25519, &v1.TimeRange{Start: timestamppb.New(now.Add(-time.Hour))})
+	rsaLog := newTestLog(t, "rsa.rekor.localhost", v1.PublicKeyDetails_PKIX_RSA_PKCS1V15_2048_SHA256, nil)
+	expiredLog := newTestLog(t, "expired.rekor.localhost", v1.PublicKeyDetails_PKIX_ECDSA_P256_SHA_256, expired)
+	untrustedLog := newTestLog(t, "ecdsa.rekor.localhost", v1.PublicKeyDetails_PKIX_ECDSA_P256_SHA_256, nil)
+	trustedRoot := &pbtr.TrustedRoot{
+		Tlogs: []*pbtr.TransparencyLogInstance{ecdsaLog.instance, ed25519Log.instance, rsaLog.instance, expiredLog.instance},
+	}
+
+	otherLogID := ecdsaLog.entry(t)
+	otherLogID.LogId = &v1.LogId{KeyId: rsaLog.instance.GetLogId().GetKeyId()}
+	noLogID := rsaLog.entry(t)
+	noLogID.LogId = nil
+	tampered := ed25519Log.entry(t)
+	tampered.CanonicalizedBody = []byte(`{"kind":"other"}`)
+	noProof := ecdsaLog.entry(t)
+	noProof.InclusionProof = nil
+
+	for _, test := range []struct {
+		name      string
+		entry     *pbs.TransparencyLogEntry
+		opts      []TrustedRootOption
+		expectErr error
+		wantErr   bool
+	}{
+		{
+			name:  "ecdsa log",
+			entry: ecdsaLog.entry(t),
+		},
+		{
+			name:  "ed25519 log",
+			entry: ed25519Log.entry(t),
+			opts:  []TrustedRootOption{WithObservedTime(now)},
+		},
+		{
+			name:      "no observed time for key with validity period",
+			entry:     ed25519Log.entry(t),
+			expectErr: ErrNoObservedTime,
+		},
+		{
+			name:  "rsa log",
+			entry: rsaLog.entry(t),
+		},
+		{
+			name:  "no log id",
+			entry: noLogID,
+		},
+		{
+			name:      "untrusted log",
+			entry:     untrustedLog.entry(t),
+			expectErr: ErrNoMatchingLog,
+		},
+		{
+			name:      "log id of another log",
+			entry:     otherLogID,
+			expectErr: ErrNoMatchingLog,
+		},
+		{
+			name:    "key expired",
+			entry:   expiredLog.entry(t),
+			opts:    []TrustedRootOption{WithObservedTime(now)},
+			wantErr: true,
+		},
+		{
+			name:  "key valid at observed time",
+			entry: expiredLog.entry(t),
+			opts:  []TrustedRootOption{WithObservedTime(now.Add(-36 * time.Hour))},
+		},
+		{
+			name:    "key not yet valid at observed time",
+			entry:   ed25519Log.entry(t),
+			opts:    []TrustedRootOption{WithObservedTime(now.Add(-2 * time.Hour))},
+			wantErr: true,
+		},
+		{
+			name:    "invalid inclusion proof",
+			entry:   tampered,
+			opts:    []TrustedRootOption{WithObservedTime(now)},
+			wantErr: true,
+		},
+		{
+			name:    "missing inclusion proof",
+			entry:   noProof,
+			wantErr: true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			err := VerifyLogEntryWithTrustedRoot(test.entry, trustedRoot, test.opts...)
+			switch {
+			case test.expectErr != nil:
+				assert.ErrorIs(t, err, test.expectErr)
+			case test.wantErr:
+				assert.Error(t, err)
+			default:
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestVerifyLogEntryWithTrustedRootWitnessPolicy(t *testing.T) {
+	log := newTestLog(t, "rekor.localhost", v1.PublicKeyDetails_PKIX_ED25519, nil)
+	trustedRoot := &pbtr.TrustedRoot{Tlogs: []*pbtr.TransparencyLogInstance{log.instance}}
+	witness := newTestCosigner(t, "witness.example")
+	policy, err := NewWitnessPolicy(1, witness.vkey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entry := log.entry(t)
+	err = VerifyLogEntryWithTrustedRoot(entry, trustedRoot, WithWitnessPolicy(policy))
+	assert.ErrorIs(t, err, ErrWitnessPolicyNotSatisfied)
+
+	entry.InclusionProof.Checkpoint.Envelope = cosign(t, entry.GetInclusionProof().GetCheckpoint().GetEnvelope(), witness)
+	assert.NoError(t, VerifyLogEntryWithTrustedRoot(entry, trustedRoot, WithWitnessPolicy(policy)))
+}
+
+func TestVerifyBundle(t *testing.T) {
+	log := newTestLog(t, "rekor.localhost", v1.PublicKeyDetails_PKIX_ECDSA_P256_SHA_256, nil)
+	untrustedLog := newTestLog(t, "rekor.localhost", v1.PublicKeyDetails_PKIX_ECDSA_P256_SHA_256, nil)
+	trustedRoot := &pbtr.TrustedRoot{Tlogs: []*pbtr.TransparencyLogInstance{log.instance}}
+
+	bundle := func(entries ...*pbs.TransparencyLogEntry) *pbbundle.Bundle {
+		return &pbbundle.Bundle{VerificationMaterial: &pbbundle.VerificationMaterial{TlogEntries: entries}}
+	}
+	assert.NoError(t, VerifyBundle(bundle(log.entry(t)), trustedRoot))
+	assert.ErrorContains(t, VerifyBundle(bundle(), trustedRoot), "no transparency log entries")
+	assert.ErrorIs(t, VerifyBundle(bundle(log.entry(t), untrustedLog.entry(t)), trustedRoot), ErrNoMatchingLog)
+}
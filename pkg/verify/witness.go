@@ -0,0 +1,345 @@
+//
+// Copyright 2025 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	pbs "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
+	f_log "github.com/transparency-dev/formats/log"
+	f_note "github.com/transparency-dev/formats/note"
+	sumdb_note "golang.org/x/mod/sumdb/note"
+)
+
+// DefaultCosignatureClockSkew is how far in the future a cosignature timestamp may be
+// before the cosignature is rejected.
+const DefaultCosignatureClockSkew = 5 * time.Minute
+
+// ErrWitnessPolicyNotSatisfied is returned when a checkpoint is not cosigned by
+// enough witnesses to satisfy a witness policy.
+var ErrWitnessPolicyNotSatisfied = errors.New("witness policy not satisfied")
+
+// WitnessPolicy is a quorum of witnesses that must cosign a checkpoint for it to be trusted.
+type WitnessPolicy struct {
+	witnesses []*policyWitness
+	// quorum is nil if no cosignatures are required
+	quorum policyComponent
+}
+
+// policyComponent is either a single witness or a group of components with a threshold.
+type policyComponent interface {
+	satisfied(signed map[*policyWitness]bool) bool
+}
+
+type policyWitness struct {
+	name     string
+	verifier sumdb_note.Verifier
+}
+
+func (w *policyWitness) satisfied(signed map[*policyWitness]bool) bool {
+	return signed[w]
+}
+
+type policyGroup struct {
+	threshold int
+	children  []policyComponent
+}
+
+func (g *policyGroup) satisfied(signed map[*policyWitness]bool) bool {
+	count := 0
+	for _, c := range g.children {
+		if c.satisfied(signed) {
+			count++
+		}
+	}
+	return count >= g.threshold
+}
+
+var policyKeywords = map[string]bool{"log": true, "witness": true, "group": true, "quorum": true, "any": true, "all": true, "none": true}
+
+// ParseWitnessPolicy parses a witness policy in the format described in
+// https://git.glasklar.is/sigsum/core/sigsum-go/-/blob/main/doc/policy.md, with
+// witness keys given as note verifier keys, the same format accepted by the
+// --witness-policy-file flag of rekor-server. For example:
+//
+//	witness w1 witness1.example+abcd1234+AeO...
+//	witness w2 witness2.example+ef567890+Af1... https://witness2.example
+//	witness w3 witness3.example+0123cdef+AX9...
+//	group g1 2 w1 w2 w3
+//	quorum g1
+//
+// Groups may be nested, and have a threshold of a number, "any" or "all" of their
+// members. Witness URLs and log lines are ignored.
+func ParseWitnessPolicy(policy []byte) (*WitnessPolicy, error) {
+	p := &WitnessPolicy{}
+	components := make(map[string]policyComponent)
+	keys := make(map[witnessKey]string)
+	quorum := ""
+	scanner := bufio.NewScanner(bytes.NewReader(policy))
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		switch fields[0] {
+		case "log":
+		case "witness":
+			if len(fields) != 3 && len(fields) != 4 {
+				return nil, fmt.Errorf("invalid witness definition: %q", line)
+			}
+			name := fields[1]
+			if err := checkPolicyName(name, components); err != nil {
+				return nil, err
+			}
+			verifier, err := f_note.NewVerifierForCosignatureV1(fields[2])
+			if err != nil {
+				return nil, fmt.Errorf("invalid key for witness %q: %w", name, err)
+			}
+			if other, ok := keys[keyOf(verifier)]; ok {
+				return nil, fmt.Errorf("witness %q has the same key as witness %q", name, other)
+			}
+			keys[keyOf(verifier)] = name
+			w := &policyWitness{name: name, verifier: verifier}
+			p.witnesses = append(p.witnesses, w)
+			components[name] = w
+		case "group":
+			if len(fields) < 4 {
+				return nil, fmt.Errorf("invalid group definition: %q", line)
+			}
+			name, children := fields[1], fields[3:]
+			if err := checkPolicyName(name, components); err != nil {
+				return nil, err
+			}
+			var threshold int
+			switch fields[2] {
+			case "any":
+				threshold = 1
+			case "all":
+				threshold = len(children)
+			default:
+				n, err := strconv.ParseUint(fields[2], 10, 8)
+				if err != nil {
+					return nil, fmt.Errorf("invalid threshold %q for group %q", fields[2], name)
+				}
+				threshold = int(n)
+			}
+			if threshold > len(children) {
+				return nil, fmt.Errorf("group %q with %d members cannot have threshold %d", name, len(children), threshold)
+			}
+			g := &policyGroup{threshold: threshold}
+			members := make(map[string]bool)
+			for _, child := range children {
+				c, ok := components[child]
+				if !ok {
+					return nil, fmt.Errorf("unknown member %q of group %q", child, name)
+				}
+				if members[child] {
+					return nil, fmt.Errorf("duplicate member %q of group %q", child, name)
+				}
+				members[child] = true
+				g.children = append(g.children, c)
+			}
+			components[name] = g
+		case "quorum":
+			if len(fields) != 2 || quorum != "" {
+				return nil, fmt.Errorf("invalid quorum definition: %q", line)
+			}
+			quorum = fields[1]
+		default:
+			return nil, fmt.Errorf("unknown keyword %q", fields[0])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	switch quorum {
+	case "":
+		return nil, fmt.Errorf("witness policy must define a quorum")
+	case "none":
+	default:
+		c, ok := components[quorum]
+		if !ok {
+			return nil, fmt.Errorf("unknown quorum %q", quorum)
+		}
+		p.quorum = c
+	}
+	return p, nil
+}
+
+func checkPolicyName(name string, components map[string]policyComponent) error {
+	if policyKeywords[name] {
+		return fmt.Errorf("invalid name %q", name)
+	}
+	if _, ok := components[name]; ok {
+		return fmt.Errorf("duplicate name %q", name)
+	}
+	return nil
+}
+
+// witnessKey identifies a witness key by its name and key hash, as cosignatures do.
+type witnessKey struct {
+	name string
+	hash uint32
+}
+
+func keyOf(v sumdb_note.Verifier) witnessKey {
+	return witnessKey{name: v.Name(), hash: v.KeyHash()}
+}
+
+// NewWitnessPolicy returns a policy requiring cosignatures from threshold of the
+// witnesses with the given note verifier keys. Witnesses are named after their keys.
+func NewWitnessPolicy(threshold int, witnessKeys ...string) (*WitnessPolicy, error) {
+	if threshold < 0 || threshold > len(witnessKeys) {
+		return nil, fmt.Errorf("threshold %d out of range for %d witnesses", threshold, len(witnessKeys))
+	}
+	p := &WitnessPolicy{}
+	g := &policyGroup{threshold: threshold}
+	keys := make(map[witnessKey]bool)
+	for _, key := range witnessKeys {
+		verifier, err := f_note.NewVerifierForCosignatureV1(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid witness key %q: %w", key, err)
+		}
+		if keys[keyOf(verifier)] {
+			return nil, fmt.Errorf("duplicate witness key %q", key)
+		}
+		keys[keyOf(verifier)] = true
+		w := &policyWitness{name: verifier.Name(), verifier: verifier}
+		p.witnesses = append(p.witnesses, w)
+		g.children = append(g.children, w)
+	}
+	if threshold > 0 {
+		p.quorum = g
+	}
+	return p, nil
+}
+
+// Cosignature is a verified witness cosignature on a checkpoint.
+type Cosignature struct {
+	// Witness is the name of the witness in the policy
+	Witness string
+	// KeyName is the name of the witness's key
+	KeyName string
+	// Timestamp is the time at which the witness cosigned the checkpoint
+	Timestamp time.Time
+}
+
+type witnessOptions struct {
+	now       time.Time
+	maxAge    time.Duration
+	clockSkew time.Duration
+}
+
+// WitnessOption configures verification of witness cosignatures.
+type WitnessOption func(*witnessOptions)
+
+// WithVerificationTime sets the time that cosignature timestamps are checked against.
+// Defaults to the current time.
+func WithVerificationTime(t time.Time) WitnessOption {
+	return func(o *witnessOptions) {
+		o.now = t
+	}
+}
+
+// WithMaxCosignatureAge rejects cosignatures made longer than maxAge before the
+// verification time. By default, the age of cosignatures is not checked.
+func WithMaxCosignatureAge(maxAge time.Duration) WitnessOption {
+	return func(o *witnessOptions) {
+		o.maxAge = maxAge
+	}
+}
+
+// WithCosignatureClockSkew sets how far in the future a cosignature timestamp may be.
+// Defaults to DefaultCosignatureClockSkew.
+func WithCosignatureClockSkew(skew time.Duration) WitnessOption {
+	return func(o *witnessOptions) {
+		o.clockSkew = skew
+	}
+}
+
+// VerifyWitnessedCheckpoint verifies the log's signature on the checkpoint and that
+// the checkpoint is cosigned by enough witnesses to satisfy the policy, returning the
+// checkpoint and the cosignatures that were verified. Cosignatures must be
+// https://github.com/C2SP/C2SP/blob/main/tlog-cosignature.md signatures, and are
+// ignored if their timestamp is in the future or, if configured, too old. If the
+// policy is not satisfied, the returned error wraps ErrWitnessPolicyNotSatisfied.
+func VerifyWitnessedCheckpoint(unverifiedCp string, verifier sumdb_note.Verifier, policy *WitnessPolicy, opts ...WitnessOption) (*f_log.Checkpoint, []Cosignature, error) { //nolint: revive
+	o := &witnessOptions{now: time.Now(), clockSkew: DefaultCosignatureClockSkew}
+	for _, opt := range opts {
+		opt(o)
+	}
+	cp, _, n, err := f_log.ParseCheckpoint([]byte(unverifiedCp), verifier.Name(), verifier)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unverified checkpoint signature: %v", err)
+	}
+	signed := make(map[*policyWitness]bool)
+	var cosigs []Cosignature
+	for _, sig := range n.UnverifiedSigs {
+		for _, w := range policy.witnesses {
+			if signed[w] || !verifyCosignature(n.Text, sig, w.verifier) {
+				continue
+			}
+			ts, err := f_note.CoSigV1Timestamp(sig)
+			if err != nil || ts.After(o.now.Add(o.clockSkew)) || (o.maxAge > 0 && ts.Before(o.now.Add(-o.maxAge))) {
+				continue
+			}
+			signed[w] = true
+			cosigs = append(cosigs, Cosignature{Witness: w.name, KeyName: sig.Name, Timestamp: ts})
+		}
+	}
+	if policy.quorum != nil && !policy.quorum.satisfied(signed) {
+		names := make([]string, 0, len(cosigs))
+		for _, c := range cosigs {
+			names = append(names, c.Witness)
+		}
+		return nil, nil, fmt.Errorf("%w: cosigned by %d witnesses %v", ErrWitnessPolicyNotSatisfied, len(names), names)
+	}
+	return cp, cosigs, nil
+}
+
+// verifyCosignature returns true if sig is a valid signature on text from verifier.
+func verifyCosignature(text string, sig sumdb_note.Signature, verifier sumdb_note.Verifier) bool {
+	if sig.Name != verifier.Name() || sig.Hash != verifier.KeyHash() {
+		return false
+	}
+	raw, err := base64.StdEncoding.DecodeString(sig.Base64)
+	if err != nil || len(raw) < 4 || binary.BigEndian.Uint32(raw) != sig.Hash {
+		return false
+	}
+	return verifier.Verify([]byte(text), raw[4:])
+}
+
+// VerifyLogEntryWithWitnessPolicy verifies the log entry like VerifyLogEntry, and also
+// verifies that the checkpoint of the inclusion proof satisfies the witness policy.
+func VerifyLogEntryWithWitnessPolicy(entry *pbs.TransparencyLogEntry, verifier sumdb_note.Verifier, policy *WitnessPolicy, opts ...WitnessOption) ([]Cosignature, error) { //nolint: revive
+	cp, cosigs, err := VerifyWitnessedCheckpoint(entry.GetInclusionProof().GetCheckpoint().GetEnvelope(), verifier, policy, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := VerifyInclusionProof(entry, cp); err != nil {
+		return nil, err
+	}
+	return cosigs, nil
+}
@@ -0,0 +1,281 @@
+//
+// Copyright 2025 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"crypto/rand"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	rekornote "github.com/sigstore/rekor-tiles/v2/pkg/note"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/stretchr/testify/assert"
+	f_note "github.com/transparency-dev/formats/note"
+	note "golang.org/x/mod/sumdb/note"
+)
+
+type testCosigner struct {
+	signer note.Signer
+	vkey   string
+}
+
+func newTestCosigner(t *testing.T, name string) testCosigner {
+	skey, vkey, err := note.GenerateKey(rand.Reader, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := f_note.NewSignerForCosignatureV1(skey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return testCosigner{signer: signer, vkey: vkey}
+}
+
+// cosign adds cosignatures from the witnesses to the signed checkpoint.
+func cosign(t *testing.T, cp string, witnesses ...testCosigner) string {
+	text, _, _ := strings.Cut(cp, "\n\n")
+	text += "\n"
+	for _, w := range witnesses {
+		signed, err := note.Sign(&note.Note{Text: text}, w.signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cp += string(signed[len(text)+1:])
+	}
+	return cp
+}
+
+func TestParseWitnessPolicy(t *testing.T) {
+	w1, w2 := newTestCosigner(t, "w1.example"), newTestCosigner(t, "w2.example")
+	for _, test := range []struct {
+		name      string
+		policy    string
+		expectErr string
+	}{
+		{
+			name:   "single witness",
+			policy: fmt.Sprintf("witness w1 %s\nquorum w1\n", w1.vkey),
+		},
+		{
+			name:   "nested groups with comments and urls",
+			policy: fmt.Sprintf("# policy\nlog %s\nwitness w1 %s https://w1.example\nwitness w2 %s\ngroup g1 any w1 w2\ngroup g2 all g1 w2 # nested\nquorum g2\n", w1.vkey, w1.vkey, w2.vkey),
+		},
+		{
+			name:   "no quorum required",
+			policy: "quorum none\n",
+		},
+		{
+			name:      "missing quorum",
+			policy:    fmt.Sprintf("witness w1 %s\n", w1.vkey),
+			expectErr: "must define a quorum",
+		},
+		{
+			name:      "unknown quorum",
+			policy:    fmt.Sprintf("witness w1 %s\nquorum w2\n", w1.vkey),
+			expectErr: "unknown quorum",
+		},
+		{
+			name:      "invalid key",
+			policy:    "witness w1 not-a-key\nquorum w1\n",
+			expectErr: "invalid key",
+		},
+		{
+			name:      "duplicate name",
+			policy:    fmt.Sprintf("witness w1 %s\nwitness w1 %s\nquorum w1\n", w1.vkey, w2.vkey),
+			expectErr: "duplicate name",
+		},
+		{
+			name:      "keyword name",
+			policy:    fmt.Sprintf("witness any %s\nquorum any\n", w1.vkey),
+			expectErr: "invalid name",
+		},
+		{
+			name:   "zero threshold",
+			policy: fmt.Sprintf("witness w1 %s\ngroup g1 0 w1\nquorum g1\n", w1.vkey),
+		},
+		{
+			name:      "invalid threshold",
+			policy:    fmt.Sprintf("witness w1 %s\ngroup g1 -1 w1\nquorum g1\n", w1.vkey),
+			expectErr: "invalid threshold",
+		},
+		{
+			name:      "threshold too large",
+			policy:    fmt.Sprintf("witness w1 %s\ngroup g1 2 w1\nquorum g1\n", w1.vkey),
+			expectErr: "cannot have threshold",
+		},
+		{
+			name:      "unknown group member",
+			policy:    fmt.Sprintf("witness w1 %s\ngroup g1 any w1 w2\nquorum g1\n", w1.vkey),
+			expectErr: "unknown member",
+		},
+		{
+			name:      "duplicate witness key",
+			policy:    fmt.Sprintf("witness w1 %s\nwitness w2 %s\ngroup g1 2 w1 w2\nquorum g1\n", w1.vkey, w1.vkey),
+			expectErr: "same key",
+		},
+		{
+			name:      "duplicate group member",
+			policy:    fmt.Sprintf("witness w1 %s\ngroup g1 2 w1 w1\nquorum g1\n", w1.vkey),
+			expectErr: "duplicate member",
+		},
+		{
+			name:      "duplicate group member with all threshold",
+			policy:    fmt.Sprintf("witness w1 %s\ngroup g1 all w1 w1\nquorum g1\n", w1.vkey),
+			expectErr: "duplicate member",
+		},
+		{
+			name:      "unknown keyword",
+			policy:    "witnesses w1\nquorum none\n",
+			expectErr: "unknown keyword",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParseWitnessPolicy([]byte(test.policy))
+			if test.expectErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.expectErr)
+			}
+		})
+	}
+}
+
+func TestNewWitnessPolicy(t *testing.T) {
+	w1, w2 := newTestCosigner(t, "w1.example"), newTestCosigner(t, "w2.example")
+	_, err := NewWitnessPolicy(2, w1.vkey, w2.vkey)
+	assert.NoError(t, err)
+	_, err = NewWitnessPolicy(3, w1.vkey, w2.vkey)
+	assert.ErrorContains(t, err, "out of range")
+	_, err = NewWitnessPolicy(2, w1.vkey, w1.vkey)
+	assert.ErrorContains(t, err, "duplicate witness key")
+}
+
+func TestVerifyWitnessedCheckpoint(t *testing.T) {
+	hostname := "rekor.localhost"
+	sv, _, err := signature.NewDefaultECDSASignerVerifier()
+	if err != nil {
+		t.Fatal(err)
+	}
+	noteVerifier, err := rekornote.NewNoteVerifier(hostname, sv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cp := getTestEntry(t, sv, hostname).GetInclusionProof().GetCheckpoint().GetEnvelope()
+	w1, w2, w3 := newTestCosigner(t, "w1.example"), newTestCosigner(t, "w2.example"), newTestCosigner(t, "w3.example")
+	unknown := newTestCosigner(t, "unknown.example")
+
+	twoOfThree, err := NewWitnessPolicy(2, w1.vkey, w2.vkey, w3.vkey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nested, err := ParseWitnessPolicy([]byte(fmt.Sprintf("witness w1 %s\nwitness w2 %s\nwitness w3 %s\ngroup g1 any w2 w3\ngroup g2 all w1 g1\nquorum g2\n", w1.vkey, w2.vkey, w3.vkey)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	none, err := ParseWitnessPolicy([]byte("quorum none\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		name          string
+		checkpoint    string
+		policy        *WitnessPolicy
+		opts          []WitnessOption
+		expectSigners []string
+		expectErr     bool
+	}{
+		{
+			name:          "threshold met",
+			checkpoint:    cosign(t, cp, w1, w3),
+			policy:        twoOfThree,
+			expectSigners: []string{"w1.example", "w3.example"},
+		},
+		{
+			name:       "threshold not met",
+			checkpoint: cosign(t, cp, w1, unknown),
+			policy:     twoOfThree,
+			expectErr:  true,
+		},
+		{
+			name:          "nested groups met",
+			checkpoint:    cosign(t, cp, w1, w3),
+			policy:        nested,
+			expectSigners: []string{"w1", "w3"},
+		},
+		{
+			name:       "nested groups not met",
+			checkpoint: cosign(t, cp, w2, w3),
+			policy:     nested,
+			expectErr:  true,
+		},
+		{
+			name:       "no quorum required",
+			checkpoint: cp,
+			policy:     none,
+		},
+		{
+			name:       "cosignatures too old",
+			checkpoint: cosign(t, cp, w1, w2),
+			policy:     twoOfThree,
+			opts:       []WitnessOption{WithVerificationTime(time.Now().Add(1 * time.Hour)), WithMaxCosignatureAge(10 * time.Minute)},
+			expectErr:  true,
+		},
+		{
+			name:          "cosignatures within maximum age",
+			checkpoint:    cosign(t, cp, w1, w2),
+			policy:        twoOfThree,
+			opts:          []WitnessOption{WithVerificationTime(time.Now().Add(5 * time.Minute)), WithMaxCosignatureAge(10 * time.Minute)},
+			expectSigners: []string{"w1.example", "w2.example"},
+		},
+		{
+			name:       "cosignatures in the future",
+			checkpoint: cosign(t, cp, w1, w2),
+			policy:     twoOfThree,
+			opts:       []WitnessOption{WithVerificationTime(time.Now().Add(-1 * time.Hour))},
+			expectErr:  true,
+		},
+		{
+			name:       "invalid log signature",
+			checkpoint: cosign(t, getTestEntry(t, sv, "other.host").GetInclusionProof().GetCheckpoint().GetEnvelope(), w1, w2),
+			policy:     twoOfThree,
+			expectErr:  true,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			gotCp, cosigs, err := VerifyWitnessedCheckpoint(test.checkpoint, noteVerifier, test.policy, test.opts...)
+			if test.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, hostname, gotCp.Origin)
+			var signers []string
+			for _, c := range cosigs {
+				signers = append(signers, c.Witness)
+				assert.WithinDuration(t, time.Now(), c.Timestamp, 1*time.Minute)
+			}
+			assert.Equal(t, test.expectSigners, signers)
+		})
+	}
+
+	_, _, err = VerifyWitnessedCheckpoint(cosign(t, cp, w1), noteVerifier, twoOfThree)
+	assert.ErrorIs(t, err, ErrWitnessPolicyNotSatisfied)
+}
@@ -25,6 +25,7 @@ import (
 	"crypto/sha256"
 	"crypto/x509"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"os/exec"
 	"strings"
@@ -36,6 +37,7 @@ import (
 	pbdsse "github.com/sigstore/protobuf-specs/gen/pb-go/dsse"
 	pbs "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
 	"github.com/sigstore/rekor-tiles/v2/internal/tessera"
+	"github.com/sigstore/rekor-tiles/v2/pkg/client"
 	"github.com/sigstore/rekor-tiles/v2/pkg/client/read"
 	"github.com/sigstore/rekor-tiles/v2/pkg/client/write"
 	pb "github.com/sigstore/rekor-tiles/v2/pkg/generated/protobuf"
@@ -86,7 +88,7 @@ func TestReadWrite(t *testing.T) {
 	}
 
 	// writer client
-	writer, err := write.NewWriter(defaultRekorURL)
+	writer, err := write.NewWriter(defaultRekorURL, client.WithVerifier(noteVerifier))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -307,6 +309,42 @@ func TestPersistentDeduplication(t *testing.T) {
 	assert.ErrorContains(t, err, "an equivalent entry already exists in the transparency log with index")
 }
 
+func TestAsyncWrite(t *testing.T) {
+	ctx := context.Background()
+
+	writer, err := write.NewAsyncWriter(defaultRekorURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientPrivKey, clientPubKey, err := genKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	hr, err := newHashedRekordRequest(clientPrivKey, clientPubKey, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tle, err := writer.AddAsync(ctx, hr)
+	assert.NoError(t, err)
+	assert.Nil(t, tle.InclusionProof)
+
+	// Poll for the inclusion proof until a checkpoint covering the entry is published
+	var withProof *pbs.TransparencyLogEntry
+	for i := 0; i <= 10; i++ {
+		withProof, err = writer.GetEntryProofFor(ctx, tle.LogIndex, hr)
+		if !errors.Is(err, write.ErrNotIntegrated) {
+			break
+		}
+		time.Sleep(1 * time.Second)
+	}
+	assert.NoError(t, err)
+	assert.Equal(t, tle.LogIndex, withProof.LogIndex)
+	assert.Equal(t, tle.CanonicalizedBody, withProof.CanonicalizedBody)
+	assert.Equal(t, "hashedrekord", withProof.KindVersion.Kind)
+	leafHash := rfc6962.DefaultHasher.HashLeaf(withProof.CanonicalizedBody)
+	assert.NoError(t, proof.VerifyInclusion(rfc6962.DefaultHasher, uint64(withProof.InclusionProof.LogIndex), uint64(withProof.InclusionProof.TreeSize), leafHash, withProof.InclusionProof.Hashes, withProof.InclusionProof.RootHash))
+}
+
 func artifactDigest(idx uint64) []byte {
 	baseArtifact := "testartifact"
 	artifact := []byte(fmt.Sprintf("%s%d", baseArtifact, idx))